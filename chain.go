@@ -0,0 +1,134 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// JobWrapper decorates a callback with cross-cutting behaviour.
+type JobWrapper func(ContextCallback) ContextCallback
+
+// Chain combines the wrappers, the first one is the outermost.
+func Chain(wrappers ...JobWrapper) JobWrapper {
+	return func(callback ContextCallback) ContextCallback {
+		for i := len(wrappers) - 1; i >= 0; i-- {
+			callback = wrappers[i](callback)
+		}
+		return callback
+	}
+}
+
+type startKey struct{}
+
+type timeoutKey struct{}
+
+// PanicError is the error Recover turns a panic into.
+type PanicError struct {
+	Value any
+	Stack string
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v\n%s", e.Value, e.Stack)
+}
+
+// wrap applies the chain of the cron, then the chain of the job. The run
+// starts when the chain calls the callback, see runJob.
+func (c *Cron) wrap(callback ContextCallback, chain []JobWrapper) ContextCallback {
+	return Chain(append(c.chain[:len(c.chain):len(c.chain)], chain...)...)(func(ctx context.Context) error {
+		if start, ok := ctx.Value(startKey{}).(func()); ok {
+			start()
+		}
+		return callback(ctx)
+	})
+}
+
+// Recover turns a panic into a *PanicError carrying the stack trace, the run
+// is still recorded as panicked.
+func Recover(logger Logger) JobWrapper {
+	return func(callback ContextCallback) ContextCallback {
+		return func(ctx context.Context) (err error) {
+			defer func() {
+				if p := recover(); p != nil {
+					err = &PanicError{Value: p, Stack: string(debug.Stack())}
+					logger.Error(err)
+				}
+			}()
+			return callback(ctx)
+		}
+	}
+}
+
+// SkipIfStillRunning skips a run while the previous one is still running,
+// the run ends with ErrRunSkipped.
+func SkipIfStillRunning(logger Logger) JobWrapper {
+	return func(callback ContextCallback) ContextCallback {
+		running := make(chan struct{}, 1)
+		return func(ctx context.Context) error {
+			select {
+			case running <- struct{}{}:
+				defer func() {
+					<-running
+				}()
+				return callback(ctx)
+			default:
+				info, _ := JobInfoFromContext(ctx)
+				logger.Info("job skipped, still running:", info.ID)
+				return ErrRunSkipped
+			}
+		}
+	}
+}
+
+// DelayIfStillRunning delays a run until the previous one has returned.
+func DelayIfStillRunning(logger Logger) JobWrapper {
+	return func(callback ContextCallback) ContextCallback {
+		var locker sync.Mutex
+		return func(ctx context.Context) error {
+			start := time.Now()
+			locker.Lock()
+			defer locker.Unlock()
+			if delay := time.Since(start); delay > time.Second {
+				info, _ := JobInfoFromContext(ctx)
+				logger.Infof("job %d delayed %s, still running", info.ID, delay)
+			}
+			return callback(ctx)
+		}
+	}
+}
+
+// Timeout cancels the context of a run taking longer than timeout, the run
+// is recorded as timed out.
+func Timeout(timeout time.Duration) JobWrapper {
+	return func(callback ContextCallback) ContextCallback {
+		return func(ctx context.Context) (err error) {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			err = callback(ctx)
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				if expire, ok := ctx.Value(timeoutKey{}).(func()); ok {
+					expire()
+				}
+			}
+			return
+		}
+	}
+}
+
+// LogDuration logs how long every run took.
+func LogDuration(logger Logger) JobWrapper {
+	return func(callback ContextCallback) ContextCallback {
+		return func(ctx context.Context) (err error) {
+			start := time.Now()
+			defer func() {
+				info, _ := JobInfoFromContext(ctx)
+				logger.Infof("job %d took %s", info.ID, time.Since(start))
+			}()
+			return callback(ctx)
+		}
+	}
+}
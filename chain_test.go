@@ -0,0 +1,152 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testWrapper(name string, order *[]string) JobWrapper {
+	return func(callback ContextCallback) ContextCallback {
+		return func(ctx context.Context) error {
+			*order = append(*order, name)
+			return callback(ctx)
+		}
+	}
+}
+
+func TestChain(t *testing.T) {
+	var order []string
+	c := New(WithChain(testWrapper("cron", &order)))
+	id := c.MustAddJob("every 1 minute", func() {
+		order = append(order, "callback")
+	}, JobChain(testWrapper("job1", &order), testWrapper("job2", &order)))
+	c.dispatch(c.jobs[id-1], time.Now())
+	c.wg.Wait()
+	if strings.Join(order, ",") != "cron,job1,job2,callback" {
+		t.Error(order)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	err := Recover(&LoggerNothing{})(func(ctx context.Context) error {
+		panic("boom")
+	})(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "boom") || !strings.Contains(err.Error(), "goroutine") {
+		t.Error(err)
+	}
+}
+
+func TestSkipIfStillRunning(t *testing.T) {
+	var runs atomic.Int32
+	callback := SkipIfStillRunning(&LoggerNothing{})(func(ctx context.Context) error {
+		runs.Add(1)
+		time.Sleep(time.Millisecond * 20)
+		return nil
+	})
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = callback(context.Background())
+		}()
+	}
+	wg.Wait()
+	if runs.Load() != 1 {
+		t.Error("runs", runs.Load())
+	}
+}
+
+func TestSkipIfStillRunning_Cron(t *testing.T) {
+	c := New()
+	var events sync.Map
+	c.AddListener(func(event Event) {
+		n, _ := events.LoadOrStore(event.Type, new(atomic.Int32))
+		n.(*atomic.Int32).Add(1)
+	})
+	release := make(chan struct{})
+	id := c.MustAddJob("every 1 minute", func() {
+		<-release
+	}, JobChain(SkipIfStillRunning(&LoggerNothing{})))
+	c.MustAddJob("@manual", func() {}, JobDependsOn(TriggerAny, id))
+	c.dispatch(c.jobs[id-1], time.Now())
+	c.dispatch(c.jobs[id-1], time.Now())
+	for c.jobs[id-1].Skipped() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	c.wg.Wait()
+
+	count := func(t EventType) int32 {
+		n, ok := events.Load(t)
+		if !ok {
+			return 0
+		}
+		return n.(*atomic.Int32).Load()
+	}
+	if count(EventJobStarted) != 1 || count(EventJobSucceeded) != 1 || count(EventJobSkipped) != 1 {
+		t.Error("events", count(EventJobStarted), count(EventJobSucceeded), count(EventJobSkipped))
+	}
+	snapshot, _ := c.GetJob(id)
+	if snapshot.Runs != 1 || snapshot.Failures != 0 {
+		t.Errorf("%+v", snapshot)
+	}
+	records, _ := c.History(id)
+	if len(records) != 2 || records[0].Outcome != OutcomeSkipped || records[1].Outcome != OutcomeSucceeded {
+		t.Error("history", records)
+	}
+	c.dependLocker.Lock()
+	triggers := len(c.triggers)
+	c.dependLocker.Unlock()
+	if triggers != 1 {
+		t.Error("triggers", triggers)
+	}
+}
+
+func TestDelayIfStillRunning(t *testing.T) {
+	var running, max atomic.Int32
+	callback := DelayIfStillRunning(&LoggerNothing{})(func(ctx context.Context) error {
+		if n := running.Add(1); n > max.Load() {
+			max.Store(n)
+		}
+		time.Sleep(time.Millisecond * 5)
+		running.Add(-1)
+		return nil
+	})
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = callback(context.Background())
+		}()
+	}
+	wg.Wait()
+	if max.Load() != 1 {
+		t.Error("max concurrency", max.Load())
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	err := Timeout(time.Millisecond * 10)(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error(err)
+	}
+}
+
+func TestLogDuration(t *testing.T) {
+	err := LogDuration(NewLoggerStdout())(func(ctx context.Context) error {
+		return nil
+	})(context.Background())
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"time"
 )
 
+// maxClockYears bounds the search for a match, Feb 29 may be 8 years away (2096 to 2104).
+const maxClockYears = 8
+
 type Clock struct {
 	year        uint16
 	duration    time.Duration
@@ -33,9 +36,14 @@ type Clock struct {
 	weekFirst   uint8
 	weekLast    uint8
 	week        uint8
+	location    *time.Location
 }
 
 func NewClock(duration time.Duration, seconds uint64, minutes uint64, hours uint64, days uint64, months uint64, weeks uint64) (clock *Clock, err error) {
+	return newClock(time.Now(), duration, seconds, minutes, hours, days, months, weeks)
+}
+
+func newClock(now time.Time, duration time.Duration, seconds uint64, minutes uint64, hours uint64, days uint64, months uint64, weeks uint64) (clock *Clock, err error) {
 	clock = &Clock{
 		duration: duration,
 		seconds:  seconds,
@@ -58,18 +66,24 @@ func NewClock(duration time.Duration, seconds uint64, minutes uint64, hours uint
 	clock.monthLast = clock.getLast(clock.months)
 	clock.weekLast = clock.getLast(clock.weeks)
 
-	now := time.Now()
-	clock.year = uint16(now.Year())
-	clock.week = uint8(now.Weekday())
-	clock.month = uint8(now.Month())
-	clock.day = uint8(now.Day())
-	clock.hour = uint8(now.Hour())
-	clock.minute = uint8(now.Minute())
-	clock.second = uint8(now.Second())
+	err = clock.reset(now)
+	return
+}
+
+// reset moves the clock to the last match not after now.
+func (c *Clock) reset(now time.Time) (err error) {
+	c.location = now.Location()
+	c.year = uint16(now.Year())
+	c.week = uint8(now.Weekday())
+	c.month = uint8(now.Month())
+	c.day = uint8(now.Day())
+	c.hour = uint8(now.Hour())
+	c.minute = uint8(now.Minute())
+	c.second = uint8(now.Second())
 
-	clock.init("month")
+	c.init("month")
 
-	err = clock.initWithWeek()
+	err = c.initWithWeek()
 	return
 }
 
@@ -99,10 +113,10 @@ func (c *Clock) init(t string) {
 			if c.months&(1<<i) > 0 {
 				v := uint8(i)
 				if v <= c.month {
-					c.month = v
 					if v == c.month {
 						c.init("day")
 					} else {
+						c.month = v
 						c.day = c.dayLast
 						c.getWeek()
 						c.hour = c.hourLast
@@ -126,11 +140,11 @@ func (c *Clock) init(t string) {
 			if c.days&(1<<i) > 0 {
 				v := uint8(i)
 				if v <= c.day {
-					c.day = v
-					c.getWeek()
 					if v == c.day {
 						c.init("hour")
 					} else {
+						c.day = v
+						c.getWeek()
 						c.hour = c.hourLast
 						c.minute = c.minuteLast
 						c.second = c.secondLast
@@ -140,20 +154,16 @@ func (c *Clock) init(t string) {
 			}
 		}
 		// last month
-		c.day = c.dayLast
-		c.getWeek()
-		c.hour = c.hourLast
-		c.minute = c.minuteLast
-		c.second = c.secondLast
+		c.getPrev("month")
 	case "hour":
 		for i := 23; i >= 0; i-- {
 			if c.hours&(1<<i) > 0 {
 				v := uint8(i)
 				if v <= c.hour {
-					c.hour = v
 					if v == c.hour {
 						c.init("minute")
 					} else {
+						c.hour = v
 						c.minute = c.minuteLast
 						c.second = c.secondLast
 					}
@@ -162,18 +172,16 @@ func (c *Clock) init(t string) {
 			}
 		}
 		// last day
-		c.hour = c.hourLast
-		c.minute = c.minuteLast
-		c.second = c.secondLast
+		c.getPrev("day")
 	case "minute":
 		for i := 59; i >= 0; i-- {
 			if c.minutes&(1<<i) > 0 {
 				v := uint8(i)
 				if v <= c.minute {
-					c.minute = v
 					if v == c.minute {
 						c.init("second")
 					} else {
+						c.minute = v
 						c.second = c.secondLast
 					}
 					return
@@ -181,8 +189,7 @@ func (c *Clock) init(t string) {
 			}
 		}
 		// last hour
-		c.minute = c.minuteLast
-		c.second = c.secondLast
+		c.getPrev("hour")
 	case "second":
 		for i := 59; i >= 0; i-- {
 			if c.seconds&(1<<i) > 0 {
@@ -194,7 +201,7 @@ func (c *Clock) init(t string) {
 			}
 		}
 		// last minute
-		c.second = c.secondLast
+		c.getPrev("minute")
 	}
 
 	return
@@ -203,22 +210,13 @@ func (c *Clock) init(t string) {
 func (c *Clock) initWithWeek() (err error) {
 	year := c.year
 	for ; ; c.getPrev("day") {
-		// TODO better way
-		if c.year-year > 1 {
+		if year-c.year > maxClockYears {
 			err = errors.New("no match")
 			return
 		}
-		for i := 0; i < 7; i++ {
-			if c.weeks&(1<<i) > 0 {
-				v := uint8(i)
-				if c.week == v {
-					return
-				}
-			}
+		if c.valid() && c.weeks&(1<<c.week) > 0 {
+			return
 		}
-		c.hour = c.hourFirst
-		c.minute = c.minuteFirst
-		c.second = c.secondFirst
 	}
 }
 
@@ -407,32 +405,29 @@ func (c *Clock) NextWithWeek() (now time.Time, err error) {
 	c.next()
 
 	for ; ; c.getNext("day") {
-		// TODO better way
-		if c.year-year > 2 {
+		if c.year-year > maxClockYears {
 			err = errors.New("no match")
 			return
 		}
-		for i := 0; i < 7; i++ {
-			if c.weeks&(1<<i) > 0 {
-				v := uint8(i)
-				if c.week == v {
-					now = c.Now()
-					return
-				}
-			}
+		if c.valid() && c.weeks&(1<<c.week) > 0 {
+			now = c.Now()
+			return
 		}
 	}
 }
 
+// valid reports whether the day exists in the month, e.g. Feb 29 only in leap years.
+func (c *Clock) valid() bool {
+	return c.day <= daysIn(int(c.year), time.Month(c.month))
+}
+
 func (c *Clock) getWeek() {
-	day, _ := time.ParseInLocation(time.DateOnly, fmt.Sprintf("%d-%d-%d", c.year, c.month, c.day), time.Local)
-	c.week = uint8(day.Weekday())
+	c.week = uint8(time.Date(int(c.year), time.Month(c.month), int(c.day), 0, 0, 0, 0, c.location).Weekday())
 	return
 }
 
 func (c *Clock) Now() time.Time {
-	now, _ := time.ParseInLocation(time.DateTime, fmt.Sprintf("%04d-%02d-%02d %02d:%02d:%02d", c.year, c.month, c.day, c.hour, c.minute, c.second), time.Local)
-	return now
+	return time.Date(int(c.year), time.Month(c.month), int(c.day), int(c.hour), int(c.minute), int(c.second), 0, c.location)
 }
 
 func (c *Clock) String() string {
@@ -44,3 +44,46 @@ func TestClock_New(t *testing.T) {
 	}
 	t.Log(now) // 22:58:01
 }
+
+func TestClock_NextLeapDay(t *testing.T) {
+	now, _ := time.ParseInLocation(time.DateTime, "2096-03-01 00:00:00", time.Local)
+	clock, err := newClock(now, time.Minute, 1, 1, 1, 1<<29, 1<<2, 0x7f)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	t.Log(clock.String()) // 2096-02-29
+	next, err := clock.NextWithWeek()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if next.Format(time.DateOnly) != "2104-02-29" {
+		t.Error("2100 is not a leap year", next)
+	}
+}
+
+func TestClock_Reset(t *testing.T) {
+	now, _ := time.ParseInLocation(time.DateTime, "2023-06-15 10:00:00", time.Local)
+	clock, err := newClock(now, time.Minute, 1, 1, 1<<12, 0xfffffffe, 0x1ffe, 0x7f)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	next, err := clock.NextWithWeek()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if next.Format(time.DateTime) != "2023-06-15 12:00:00" {
+		t.Error("next", next)
+	}
+}
+
+func TestIsLeap(t *testing.T) {
+	for year, leap := range map[int]bool{2000: true, 2023: false, 2024: true, 2100: false} {
+		if isLeap(year) != leap {
+			t.Error(year)
+		}
+	}
+}
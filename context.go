@@ -0,0 +1,85 @@
+package cron
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// JobInfo describes the run a ContextCallback is called for.
+type JobInfo struct {
+	ID        uint32
+	Name      string
+	Scheduled time.Time
+	Attempt   int
+	// CorrelationID is shared by the runs of a chain of dependent jobs.
+	CorrelationID string
+	// Delay is how long the rate limit held the run back.
+	Delay time.Duration
+
+	// handle is set for a run started by RunNow
+	handle *RunHandle
+}
+
+type jobInfoKey struct{}
+
+// JobInfoFromContext returns the JobInfo of the run the context belongs to.
+func JobInfoFromContext(ctx context.Context) (info JobInfo, ok bool) {
+	info, ok = ctx.Value(jobInfoKey{}).(JobInfo)
+	return
+}
+
+// run is one execution of a job, its context is cancelled when the cron
+// stops, the job is removed or the timeout is exceeded.
+type run struct {
+	info     JobInfo
+	callback ContextCallback
+	parent   context.Context
+	ctx      context.Context
+	cancel   context.CancelFunc
+	timedOut atomic.Bool
+	detached bool
+}
+
+func (j *Job) newRun(parent context.Context, info JobInfo) (r *run) {
+	info.ID = j.id
+	info.Name = j.name
+	if info.Attempt == 0 {
+		info.Attempt = 1
+	}
+	r = &run{
+		info:   info,
+		parent: parent,
+	}
+	r.ctx, r.cancel = context.WithCancel(context.WithValue(parent, jobInfoKey{}, r.info))
+
+	j.locker.Lock()
+	defer j.locker.Unlock()
+	if j.runs == nil {
+		j.runs = make(map[*run]struct{})
+	}
+	j.runs[r] = struct{}{}
+	// the callback may be replaced while the run waits in the pool
+	r.callback = j.wrapped
+	if j.Deleted {
+		r.cancel()
+	}
+	return
+}
+
+func (j *Job) endRun(r *run) {
+	r.cancel()
+
+	j.locker.Lock()
+	defer j.locker.Unlock()
+	delete(j.runs, r)
+}
+
+// cancelRuns cancels the context of every run in flight.
+func (j *Job) cancelRuns() {
+	j.locker.Lock()
+	defer j.locker.Unlock()
+	for r := range j.runs {
+		r.cancel()
+	}
+}
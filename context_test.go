@@ -0,0 +1,80 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestJobInfoFromContext(t *testing.T) {
+	c := New()
+	done := make(chan JobInfo, 1)
+	id, err := c.AddContextJob("every 1 minute", func(ctx context.Context) error {
+		info, _ := JobInfoFromContext(ctx)
+		done <- info
+		return nil
+	}, JobName("report"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	scheduled := time.Now().Truncate(time.Minute)
+	c.runJob(c.jobs[id-1], c.jobs[id-1].newRun(c.ctx, JobInfo{Scheduled: scheduled}))
+	info := <-done
+	if info.ID != id || info.Name != "report" || !info.Scheduled.Equal(scheduled) || info.Attempt != 1 {
+		t.Errorf("%+v", info)
+	}
+
+	if _, ok := JobInfoFromContext(context.Background()); ok {
+		t.Error("no info expected")
+	}
+}
+
+func TestCron_RemoveJobCancel(t *testing.T) {
+	c := New()
+	started := make(chan struct{})
+	done := make(chan error, 1)
+	id, err := c.AddContextJob("every 1 minute", func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		done <- ctx.Err()
+		return nil
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	go c.runJob(c.jobs[id-1], c.jobs[id-1].newRun(c.ctx, JobInfo{Scheduled: time.Now()}))
+	<-started
+	c.MustRemoveJob(id)
+	select {
+	case err = <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Error(err)
+		}
+	case <-time.After(time.Second):
+		t.Error("not cancelled")
+	}
+}
+
+func TestJobTimeout(t *testing.T) {
+	c := New()
+	done := make(chan error, 1)
+	id, err := c.AddContextJob("every 1 minute", func(ctx context.Context) error {
+		<-ctx.Done()
+		done <- ctx.Err()
+		return ctx.Err()
+	}, JobTimeout(time.Millisecond*10))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	c.runJob(c.jobs[id-1], c.jobs[id-1].newRun(c.ctx, JobInfo{Scheduled: time.Now()}))
+	if err = <-done; !errors.Is(err, context.DeadlineExceeded) {
+		t.Error(err)
+	}
+}
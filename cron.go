@@ -1,29 +1,72 @@
 package cron
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"runtime/debug"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
 )
 
 type Cron struct {
-	id           atomic.Uint32
-	interval     time.Duration
-	ticker       *time.Ticker
-	jobs         []*Job
-	stopChannel  chan struct{}
-	running      bool
-	locker       sync.Mutex
-	logger       Logger
-	lastMoment   uint32
-	divisibility bool
+	id          atomic.Uint32
+	interval    time.Duration
+	jobs        []*Job
+	stopChannel chan struct{}
+	state       atomic.Int32
+	lifecycle   sync.Mutex
+	// announced are the lifecycle events, emitted by unlockLifecycle
+	announced      []Event
+	locker         sync.Mutex
+	logger         Logger
+	divisibility   bool
+	jumpThreshold  time.Duration
+	nowFunc        func() time.Time
+	timeout        time.Duration
+	timeoutGrace   time.Duration
+	chain          []JobWrapper
+	eventBuffer    int
+	events         chan Event
+	subscribed     atomic.Bool
+	droppedEvents  atomic.Uint64
+	listeners      []Listener
+	listenerLocker sync.RWMutex
+	historySize    int
+	groups         map[string]*group
+	rateLimit      *bucket
+	tagRateLimits  map[string]*bucket
+	delayed        []*delayed
+	dependLocker   sync.Mutex
+	dependents     map[uint32][]*Job
+	triggers       []JobInfo
+	names          map[string]uint32
+	slot           uint64
+	paused         bool
+	// turning is set while the loop keeps the jobs on the wheel
+	turning       bool
+	retries       []*retry
+	retryLocker   sync.Mutex
+	ctx           context.Context
+	cancel        context.CancelFunc
+	done          chan struct{}
+	wg            sync.WaitGroup
+	workers       int
+	queueSize     int
+	backPressure  BackPressure
+	priorityAging time.Duration
+	pool          *pool
+	// tasks are the runs submitted under c.locker, pushed by unlock
+	tasks []*task
+	// posted are the events raised under c.locker, emitted by unlock
+	posted []Event
 }
 
 func New(options ...Options) (c *Cron) {
-	c = &Cron{
-		stopChannel: make(chan struct{}),
-	}
+	c = &Cron{}
+	c.ctx, c.cancel = context.WithCancel(context.Background())
 
 	for _, v := range options {
 		v(c)
@@ -33,10 +76,37 @@ func New(options ...Options) (c *Cron) {
 		c.interval = time.Minute
 	}
 
+	if c.jumpThreshold == 0 {
+		c.jumpThreshold = c.interval
+	}
+
 	if c.logger == nil {
 		c.logger = Logger(&LoggerNothing{})
 	}
 
+	if c.nowFunc == nil {
+		c.nowFunc = time.Now
+	}
+
+	if c.priorityAging == 0 {
+		c.priorityAging = c.interval
+	}
+
+	if c.workers > 0 {
+		c.pool = newPool(c.workers, c.queueSize, c.backPressure, c.priorityAging, c.work)
+	}
+
+	if c.historySize == 0 {
+		c.historySize = defaultHistorySize
+	}
+
+	if c.eventBuffer == 0 {
+		c.eventBuffer = 100
+	}
+	c.events = make(chan Event, c.eventBuffer)
+	c.names = make(map[string]uint32)
+	c.dependents = make(map[uint32][]*Job)
+
 	return
 }
 
@@ -51,82 +121,471 @@ func (c *Cron) Start() (err error) {
 		return
 	}
 
-	if c.running {
+	c.lifecycle.Lock()
+	defer c.unlockLifecycle()
+
+	return c.start()
+}
+
+func (c *Cron) start() (err error) {
+	if state := c.State(); state != StateCreated && state != StateStopped {
 		err = errors.New("cron already running")
 		c.logger.Error(err)
 		return
 	}
 
-	now := time.Now()
+	c.state.Store(int32(StateStarting))
+	c.stopChannel = make(chan struct{})
+	c.done = make(chan struct{})
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+	if c.pool != nil {
+		c.pool.open()
+	}
+	go c.loop(c.stopChannel, c.done)
+
+	c.state.Store(int32(StateRunning))
+	c.logger.Info("cron started")
+	c.announce(Event{Type: EventCronStarted})
+	return
+}
+
+// announce queues a lifecycle event until unlockLifecycle, so the listeners
+// can start or stop the cron. The caller holds c.lifecycle.
+func (c *Cron) announce(event Event) {
+	if event.Time.IsZero() {
+		event.Time = c.nowFunc()
+	}
+	c.announced = append(c.announced, event)
+}
+
+// unlockLifecycle releases c.lifecycle, then emits the lifecycle events.
+func (c *Cron) unlockLifecycle() {
+	events := c.announced
+	c.announced = nil
+	c.lifecycle.Unlock()
+
+	for _, event := range events {
+		c.emit(event)
+	}
+}
+
+// loop aligns to the next whole second/minute, then turns the wheel until stop is closed.
+func (c *Cron) loop(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	now := c.nowFunc()
 	var nextTime time.Time
 	if c.interval == time.Second {
 		nextTime = time.Unix(now.Unix(), 0).Add(time.Second)
-		time.Sleep(time.Duration(nextTime.UnixNano() - now.UnixNano()))
 	} else {
 		nextTime = time.Unix(now.Unix()-int64(now.Second()), 0).Add(time.Minute)
-		time.Sleep(time.Duration(nextTime.UnixNano() - now.UnixNano()))
+	}
+	timer := time.NewTimer(time.Duration(nextTime.UnixNano() - now.UnixNano()))
+	select {
+	case <-timer.C:
+	case <-stop:
+		timer.Stop()
+		return
 	}
 	now = nextTime
-	c.ticker = time.NewTicker(c.interval)
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
 
-	go func() {
-		c.lastMoment = LastMoment(c.interval)
+	c.locker.Lock()
+	slot := SlotSinceEpoch(now, c.interval)
+	for _, job := range c.jobs {
+		if job.Deleted || job.completed {
+			continue
+		}
+		c.schedule(job, now)
+	}
+	c.turning = true
+	c.tick(slot)
+	c.unlock()
+	defer func() {
+		c.locker.Lock()
+		c.turning = false
+		c.unlock()
+	}()
 
-		slot := SlotSinceYear(now, c.interval)
-		for _, job := range c.jobs {
-			if job.Deleted {
-				continue
+	expected := now
+	realign := false
+	for {
+		select {
+		case <-ticker.C:
+			// the drift is measured on the clock the slots come from
+			now = c.nowFunc()
+			if realign {
+				ticker.Reset(c.interval)
+				realign = false
 			}
-			if err = job.Next(c.interval); err != nil {
-				c.logger.Error(err)
-				continue
-			}
-			if job.Slot() == slot {
-				go c.runJob(job)
+			c.locker.Lock()
+			expected = expected.Add(c.interval)
+			if drift := now.Round(0).Sub(expected); drift > c.jumpThreshold || drift < -c.jumpThreshold {
+				c.logger.Error("cron clock jumped, resync:", drift)
+				expected = now.Truncate(c.interval)
+				slot = c.resync(expected, drift > 0)
+				// tick on the wall clock boundaries again
+				ticker.Reset(expected.Add(c.interval).Sub(now))
+				realign = true
+			} else {
+				slot++
 			}
+			c.tick(slot)
+			c.unlock()
+		case <-stop:
+			return
 		}
+	}
+}
+
+// State returns the lifecycle state.
+func (c *Cron) State() State {
+	return State(c.state.Load())
+}
+
+func (c *Cron) MustRestart() {
+	_ = c.Restart()
+}
 
-		for {
-			select {
-			case now = <-c.ticker.C:
-				if slot == c.lastMoment {
-					slot = 0
-					c.lastMoment = LastMoment(c.interval)
-				} else {
-					slot++
-				}
-				for _, job := range c.jobs {
-					if job.Deleted {
-						continue
-					}
-					if job.Slot() == slot {
-						go c.runJob(job)
-					}
-				}
-			case <-c.stopChannel:
-				return
+// Restart stops the cron if it is running and starts it again.
+func (c *Cron) Restart() (err error) {
+	if c == nil {
+		err = errors.New("cron nil")
+		c.logger.Error(err)
+		return
+	}
+
+	c.lifecycle.Lock()
+	defer c.unlockLifecycle()
+
+	if c.State() == StateRunning {
+		c.stop()
+		c.cancel()
+		c.state.Store(int32(StateStopped))
+		c.logger.Info("cron stopped")
+		c.announce(Event{Type: EventCronStopped})
+	}
+
+	return c.start()
+}
+
+// Run starts the cron and blocks until ctx is done, then stops it. It
+// returns nil when the cron is stopped by someone else first.
+func (c *Cron) Run(ctx context.Context) (err error) {
+	if err = c.Start(); err != nil {
+		return
+	}
+
+	c.lifecycle.Lock()
+	done := c.done
+	c.lifecycle.Unlock()
+
+	select {
+	case <-ctx.Done():
+		_ = c.Stop()
+		err = ctx.Err()
+	case <-done:
+	}
+	return
+}
+
+// schedule puts a job on the wheel, runs before now are not misfires.
+func (c *Cron) schedule(job *Job, now time.Time) {
+	if job.immediate {
+		job.immediate = false
+		c.dispatch(job, now)
+	}
+	if err := job.Next(c.interval); err != nil {
+		c.logger.Error(err)
+		return
+	}
+	job.forget()
+	c.settle(job)
+}
+
+// tick fires the retries and jobs of the slot.
+func (c *Cron) tick(slot uint64) {
+	c.slot = slot
+	if c.paused {
+		return
+	}
+	c.fireRetries(slot)
+	c.fireTriggers()
+	c.fireDelayed()
+	var due []*Job
+	for _, job := range c.jobs {
+		if job.Deleted || job.paused || job.completed || job.manual {
+			continue
+		}
+		if job.slot == slot {
+			due = append(due, job)
+		}
+	}
+	// higher priority first, the order of adding among equals
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].priority > due[j].priority
+	})
+	for _, job := range due {
+		c.fire(job)
+	}
+}
+
+// fire runs the due job and moves it to its next slot.
+func (c *Cron) fire(job *Job) {
+	c.dispatch(job, job.nextTime)
+	if err := job.Next(c.interval); err != nil {
+		c.logger.Error(err)
+		return
+	}
+	c.settle(job)
+}
+
+// settle reports the next run of the job and hands its missed runs to the
+// misfire policy, a job with no run left is completed.
+func (c *Cron) settle(job *Job) {
+	if !job.completed && !job.manual {
+		c.postScheduled(job)
+	}
+	c.misfire(job)
+	if job.completed {
+		c.complete(job)
+	}
+}
+
+// resync moves the wheel to now after the wall clock jumped. Jobs left
+// behind by a forward jump are handed to the misfire policy, a backward jump
+// moves every job back to now.
+func (c *Cron) resync(now time.Time, forward bool) (slot uint64) {
+	slot = SlotSinceEpoch(now, c.interval)
+	for _, job := range c.jobs {
+		if job.Deleted || job.completed || job.manual {
+			continue
+		}
+		if forward {
+			if !c.paused && !job.paused && job.nextTime.Before(now) {
+				c.catchUp(job)
 			}
+			continue
 		}
-	}()
+		if err := job.reset(now); err != nil {
+			c.logger.Error(err)
+			continue
+		}
+		if err := job.Next(c.interval); err != nil {
+			c.logger.Error(err)
+			continue
+		}
+		c.settle(job)
+	}
+	return
+}
 
-	c.running = true
-	c.logger.Info("cron started")
+// catchUp moves a job left behind by the wheel to its next slot, the runs it
+// missed go to the misfire policy.
+func (c *Cron) catchUp(job *Job) {
+	for {
+		job.miss(job.nextTime)
+		if err := job.Next(c.interval); err != nil {
+			c.logger.Error(err)
+			return
+		}
+		// the slot of the wheel may be ticked already
+		if job.completed || job.manual || job.slot > c.slot {
+			break
+		}
+	}
+	c.settle(job)
+}
+
+// dispatch runs the job in its own goroutine or the worker pool, tracked
+// until it returns.
+func (c *Cron) dispatch(job *Job, scheduled time.Time) {
+	c.dispatchRun(job, JobInfo{Scheduled: scheduled})
+}
+
+// dispatchRun dispatches the run described by info. Every scheduled run
+// counts against the max runs, a run not triggered by another job starts a
+// new correlation.
+func (c *Cron) dispatchRun(job *Job, info JobInfo) {
+	if job.maxRuns > 0 && job.fires.Load() >= job.maxRuns {
+		return
+	}
+	job.fires.Add(1)
+	info.Attempt = 1
+	if info.CorrelationID == "" {
+		info.CorrelationID = newCorrelationID()
+	}
+	if !c.limit(job, info) {
+		return
+	}
+	c.submit(job, info)
+}
+
+// submit dispatches a run of the job, see dispatch. The caller holds
+// c.locker and releases it with unlock.
+func (c *Cron) submit(job *Job, info JobInfo) {
+	start, skipped := job.admit(info)
+	if skipped {
+		c.logger.Info("job skipped, still running:", job.id)
+		c.postJob(EventJobSkipped, job, info, nil)
+		info.handle.finish(ErrRunSkipped)
+	}
+	if !start {
+		return
+	}
+
+	t := c.newTask(job, c.ctx, info)
+	if c.pool == nil {
+		go c.run(t)
+		return
+	}
+	// a full pool must not block under c.locker, unlock pushes it
+	c.tasks = append(c.tasks, t)
+}
+
+// enqueue runs an admitted run, the caller does not hold c.locker.
+func (c *Cron) enqueue(job *Job, ctx context.Context, info JobInfo) {
+	c.run(c.newTask(job, ctx, info))
+}
+
+// newTask starts tracking an admitted run.
+func (c *Cron) newTask(job *Job, ctx context.Context, info JobInfo) (t *task) {
+	t = &task{
+		job:      job,
+		run:      job.newRun(ctx, info),
+		ctx:      ctx,
+		priority: job.priority,
+	}
+	c.wg.Add(1)
 	return
 }
 
-func (c *Cron) runJob(job *Job) {
+// run hands the task to its own goroutine or the worker pool once its
+// concurrency group has room, a task held back is handed over by the run
+// of the group ending first.
+func (c *Cron) run(t *task) {
+	for t != nil {
+		ok, held := t.job.group.admit(t)
+		if ok {
+			c.hand(t)
+			return
+		}
+		if held {
+			return
+		}
+		c.logger.Infof("job %d skipped, concurrency group %s saturated", t.job.id, t.job.group.name)
+		t = c.skipTask(t, ErrGroupSaturated)
+	}
+}
+
+// hand gives the task, admitted by its group, to its own goroutine or the
+// worker pool.
+func (c *Cron) hand(t *task) {
+	if c.pool == nil {
+		go c.work(t)
+		return
+	}
+	if dropped := c.pool.push(t); dropped != nil {
+		c.drop(dropped)
+	}
+}
+
+// unlock releases c.locker, then emits the events posted under it and
+// pushes the runs submitted under it to the worker pool. A full pool blocks
+// the caller without holding c.locker, so the callbacks can still call the
+// cron and the queue drains.
+func (c *Cron) unlock() {
+	events, tasks := c.posted, c.tasks
+	c.posted, c.tasks = nil, nil
+	c.locker.Unlock()
+
+	for _, event := range events {
+		c.emit(event)
+	}
+	for _, t := range tasks {
+		c.run(t)
+	}
+}
+
+// runJob calls the callback, a panic is returned as an error.
+func (c *Cron) runJob(job *Job, r *run) (err error) {
+	record := RunRecord{
+		Scheduled:     r.info.Scheduled,
+		Start:         c.nowFunc(),
+		Attempt:       r.info.Attempt,
+		Delay:         r.info.Delay,
+		CorrelationID: r.info.CorrelationID,
+	}
+	defer job.endRun(r)
 	defer func() {
-		if err := recover(); err != nil {
-			c.logger.Error("job run err:", err)
+		record.End = c.nowFunc()
+		record.Duration = record.End.Sub(record.Start)
+		switch {
+		case record.PanicStack != "":
+			record.Outcome = OutcomePanicked
+		case errors.Is(err, ErrRunSkipped):
+			record.Outcome = OutcomeSkipped
+		case errors.Is(r.ctx.Err(), context.DeadlineExceeded):
+			// the deadline may beat the timeout timer
+			job.expire(r)
+			record.Outcome = OutcomeTimedOut
+		case r.timedOut.Load():
+			record.Outcome = OutcomeTimedOut
+		case err != nil:
+			record.Outcome = OutcomeFailed
 		}
-	}()
-	go func() {
-		err := job.Next(c.interval)
 		if err != nil {
-			c.logger.Error(err)
+			record.Err = err.Error()
 		}
+		job.record(record)
 	}()
-	job.Callback()
+	defer c.watch(job, r)()
+	defer func() {
+		if p := recover(); p != nil {
+			c.logger.Error("job run err:", p)
+			err = fmt.Errorf("panic: %v", p)
+			record.PanicStack = string(debug.Stack())
+			job.failures.Add(1)
+			c.emitJob(EventJobPanicked, job, r.info, err)
+		}
+	}()
+	// a wrapper such as SkipIfStillRunning may not call the callback
+	var once sync.Once
+	start := func() {
+		once.Do(func() {
+			job.start(record.Start)
+			c.emitJob(EventJobStarted, job, r.info, nil)
+		})
+	}
+	// the Timeout wrapper reports its deadline
+	expire := func() {
+		job.expire(r)
+	}
+	ctx := context.WithValue(context.WithValue(r.ctx, startKey{}, start), timeoutKey{}, expire)
+	if err = r.callback(ctx); errors.Is(err, ErrRunSkipped) {
+		job.locker.Lock()
+		job.skipped++
+		job.locker.Unlock()
+		c.emitJob(EventJobSkipped, job, r.info, err)
+		return
+	}
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) {
+		// recovered by the Recover wrapper
+		record.PanicStack = panicErr.Stack
+		job.failures.Add(1)
+		c.emitJob(EventJobPanicked, job, r.info, err)
+		return
+	}
+	if err != nil {
+		job.failures.Add(1)
+		c.logger.Error("job run err:", err)
+		c.emitJob(EventJobFailed, job, r.info, err)
+		return
+	}
+	c.emitJob(EventJobSucceeded, job, r.info, nil)
+	return
 }
 
 func (c *Cron) MustStop() {
@@ -140,31 +599,141 @@ func (c *Cron) Stop() (err error) {
 		return
 	}
 
-	if !c.running {
+	c.lifecycle.Lock()
+	defer c.unlockLifecycle()
+
+	if c.State() != StateRunning {
 		err = errors.New("cron not running")
 		c.logger.Error(err)
 		return
 	}
 
+	c.stop()
+	c.cancel()
+	c.state.Store(int32(StateStopped))
+	c.logger.Info("cron stopped")
+	c.announce(Event{Type: EventCronStopped})
+
+	return
+}
+
+// stop stops dispatching, it returns once the wheel goroutine has exited.
+func (c *Cron) stop() {
+	c.state.Store(int32(StateStopping))
 	close(c.stopChannel)
-	c.ticker.Stop()
+	<-c.done
+	// nothing waiting on the wheel outlives the stop
+	c.dropRetries()
+	c.dropDelayed()
+	if c.pool != nil {
+		// the workers exit once the queue is drained
+		c.pool.close()
+	}
+}
 
-	c.running = false
-	c.logger.Info("cron stopped")
+// Shutdown stops dispatching new runs and waits for the running ones to
+// return. When ctx is done first, their contexts are cancelled and a
+// *ShutdownError lists the jobs still running.
+func (c *Cron) Shutdown(ctx context.Context) (err error) {
+	if c == nil {
+		err = errors.New("cron nil")
+		c.logger.Error(err)
+		return
+	}
+
+	c.lifecycle.Lock()
+	defer c.unlockLifecycle()
 
+	if c.State() != StateRunning {
+		err = errors.New("cron not running")
+		c.logger.Error(err)
+		return
+	}
+
+	c.stop()
+
+	done := make(chan struct{})
+	go func() {
+		c.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		c.cancel()
+		c.logger.Info("cron shutdown")
+	case <-ctx.Done():
+		running := c.runningJobs()
+		c.cancel()
+		err = &ShutdownError{Err: ctx.Err(), Running: running}
+		c.logger.Error(err)
+	}
+	c.state.Store(int32(StateStopped))
+	c.announce(Event{Type: EventCronStopped})
 	return
 }
 
-func (c *Cron) MustAddJob(spec string, callback Callback) (id uint32) {
+// runningJobs returns the ids of the jobs with runs in flight.
+func (c *Cron) runningJobs() (ids []uint32) {
+	c.locker.Lock()
+	defer c.unlock()
+
+	for _, job := range c.jobs {
+		job.locker.Lock()
+		if len(job.runs) > 0 {
+			ids = append(ids, job.id)
+		}
+		job.locker.Unlock()
+	}
+	return
+}
+
+// ShutdownError is returned by Shutdown when jobs were still running at the deadline.
+type ShutdownError struct {
+	Err     error
+	Running []uint32
+}
+
+func (e *ShutdownError) Error() string {
+	return fmt.Sprintf("cron shutdown: %v, jobs still running: %v", e.Err, e.Running)
+}
+
+func (e *ShutdownError) Unwrap() error {
+	return e.Err
+}
+
+func (c *Cron) MustAddJob(spec string, callback Callback, options ...JobOptions) (id uint32) {
+	var err error
+	id, err = c.AddJob(spec, callback, options...)
+	if err != nil {
+		c.logger.Error(err)
+	}
+	return
+}
+
+func (c *Cron) AddJob(spec string, callback Callback, options ...JobOptions) (id uint32, err error) {
+	var contextCallback ContextCallback
+	if callback != nil {
+		contextCallback = callback.context()
+	}
+	return c.addJob(spec, callback, contextCallback, options)
+}
+
+func (c *Cron) MustAddContextJob(spec string, callback ContextCallback, options ...JobOptions) (id uint32) {
 	var err error
-	id, err = c.AddJob(spec, callback)
+	id, err = c.AddContextJob(spec, callback, options...)
 	if err != nil {
 		c.logger.Error(err)
 	}
 	return
 }
 
-func (c *Cron) AddJob(spec string, callback Callback) (id uint32, err error) {
+// AddContextJob adds a job whose callback gets a context, see ContextCallback.
+func (c *Cron) AddContextJob(spec string, callback ContextCallback, options ...JobOptions) (id uint32, err error) {
+	return c.addJob(spec, nil, callback, options)
+}
+
+func (c *Cron) addJob(spec string, callback Callback, contextCallback ContextCallback, options []JobOptions) (id uint32, err error) {
 	if c == nil {
 		err = errors.New("cron nil")
 		c.logger.Error(err)
@@ -177,28 +746,76 @@ func (c *Cron) AddJob(spec string, callback Callback) (id uint32, err error) {
 		return
 	}
 
-	if callback == nil {
+	if contextCallback == nil {
 		err = errors.New("callback is nil")
 		c.logger.Error(err)
 		return
 	}
 
 	job := &Job{
-		Callback: callback,
+		Callback:     callback,
+		callback:     contextCallback,
+		nowFunc:      c.nowFunc,
+		timeout:      c.timeout,
+		timeoutGrace: c.timeoutGrace,
+		divisibility: c.divisibility,
+		historySize:  c.historySize,
+	}
+	for _, v := range options {
+		v(job)
 	}
+	job.wrapped = c.wrap(job.callback, job.chain)
 
-	if err = job.Init(spec, c.interval, c.divisibility); err != nil {
+	if job.jitter > 0 && job.jitter < c.interval {
+		err = errors.New("jitter shorter than the interval")
 		c.logger.Error(err)
 		return
 	}
 
+	if job.groupName != "" {
+		if job.group = c.groups[job.groupName]; job.group == nil {
+			err = errors.New("group not exists")
+			c.logger.Error(err)
+			return
+		}
+	}
+
 	c.locker.Lock()
-	defer c.locker.Unlock()
+	defer c.unlock()
+
+	if _, ok := c.names[job.name]; ok {
+		err = errors.New("job name exists")
+		c.logger.Error(err)
+		return
+	}
+	if err = c.checkUpstream(0, job.upstream); err != nil {
+		c.logger.Error(err)
+		return
+	}
+
+	// the hash of an unnamed job is seeded with its id, so the id is known
+	// before the spec is parsed
+	job.id = c.id.Load() + 1
+	if err = job.Init(spec, c.interval, job.divisibility); err != nil {
+		c.logger.Error(err)
+		return
+	}
 
+	id = c.id.Add(1)
 	c.jobs = append(c.jobs, job)
+	if len(job.upstream) > 0 {
+		c.depend(job, job.trigger, job.upstream)
+	}
+	if job.name != "" {
+		c.names[job.name] = id
+	}
+	// a cron not turning yet schedules it when it starts
+	if c.turning {
+		c.schedule(job, c.nowFunc())
+	}
 
-	c.logger.Info("job next time:", c.id.Load(), job.nextTime)
-	c.id.Add(1)
+	c.logger.Info("job next time:", id, job.nextTime)
+	c.post(Event{Type: EventJobAdded, JobID: id, Name: job.name, Next: job.nextTime})
 	return
 }
 
@@ -215,22 +832,65 @@ func (c *Cron) RemoveJob(id uint32) (err error) {
 		return
 	}
 
+	c.locker.Lock()
+	defer c.unlock()
+
+	job, err := c.lookup(id)
+	if err != nil {
+		c.logger.Error(err)
+		return
+	}
+
+	c.removeJob(job)
+	return
+}
+
+// removeJob tombstones the job and cancels its runs, the caller holds c.locker.
+func (c *Cron) removeJob(job *Job) {
+	c.unregister(job)
+	job.cancelRuns()
+}
+
+// unregister tombstones the job, its runs in flight go on.
+func (c *Cron) unregister(job *Job) {
+	if job.name != "" && c.names[job.name] == job.id {
+		delete(c.names, job.name)
+	}
+	c.undepend(job)
+	// the runs read it under the job locker, see newRun
+	job.locker.Lock()
+	job.Deleted = true
+	job.locker.Unlock()
+	c.logger.Info("job remove success")
+	c.post(Event{Type: EventJobRemoved, JobID: job.id, Name: job.name})
+}
+
+// complete reports a job with no run left, the window ended or the max runs
+// were dispatched. Its last run may still be in flight.
+func (c *Cron) complete(job *Job) {
+	c.logger.Info("job completed:", job.id)
+	c.post(Event{Type: EventJobCompleted, JobID: job.id, Name: job.name})
+	if job.onComplete != nil {
+		// the hook may call back into the cron
+		go job.onComplete(job.snapshot())
+	}
+	if job.autoRemove {
+		c.unregister(job)
+	}
+}
+
+// lookup returns the job of the id, the caller holds c.locker.
+func (c *Cron) lookup(id uint32) (job *Job, err error) {
 	if id == 0 {
 		err = errors.New("id 0")
-		c.logger.Error(err)
 		return
 	}
 
-	if id > c.id.Load() {
+	if int(id) > len(c.jobs) {
 		err = errors.New("job not exists")
-		c.logger.Error(err)
 		return
 	}
 
-	c.locker.Lock()
-	defer c.locker.Unlock()
-
-	c.jobs[id].Deleted = true
-	c.logger.Info("job remove success")
+	job = c.jobs[id-1]
 	return
 }
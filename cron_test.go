@@ -1,12 +1,17 @@
 package cron
 
 import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestNew(t *testing.T) {
 	c := New()
-	t.Log("running", c.running)
+	t.Log("state", c.State())
 }
 
 func TestCron_AddJobCallbackNil(t *testing.T) {
@@ -70,7 +75,7 @@ func TestCron_Stop(t *testing.T) {
 	c := New(WithSecond(), WithStdout())
 	c.MustStart()
 	c.MustStop()
-	t.Log("running", c.running)
+	t.Log("state", c.State())
 }
 
 func TestCron_StartWhenRunning(t *testing.T) {
@@ -85,3 +90,256 @@ func TestCron_ReStart(t *testing.T) {
 	c.MustStop()
 	c.MustStart()
 }
+
+func TestCron_ResyncForward(t *testing.T) {
+	var runs atomic.Int32
+	c := New(WithSecond())
+	id, err := c.AddJob("every 2 seconds", func() {
+		runs.Add(1)
+	}, JobMisfire(MisfireFireOnce))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	job := c.jobs[id-1]
+	job.nextTime = time.Now().Add(-time.Minute).Truncate(time.Second)
+
+	now := time.Now().Truncate(time.Second)
+	slot := c.resync(now, true)
+	time.Sleep(time.Millisecond * 10)
+	if runs.Load() != 1 {
+		t.Error("runs", runs.Load())
+	}
+	if job.nextTime.Before(now) {
+		t.Error("next time", job.nextTime)
+	}
+	if slot != SlotSinceEpoch(now, time.Second) {
+		t.Error("slot", slot)
+	}
+}
+
+func TestCron_NowFuncNoResync(t *testing.T) {
+	var runs atomic.Int32
+	// a clock an hour behind the ticker is not a jump
+	c := New(WithSecond(), WithNowFunc(func() time.Time {
+		return time.Now().Add(-time.Hour)
+	}))
+	c.MustAddJob("* * * * * *", func() {
+		runs.Add(1)
+	})
+	c.MustStart()
+	time.Sleep(time.Millisecond * 2500)
+	c.MustStop()
+	if runs.Load() < 2 {
+		t.Error("runs", runs.Load())
+	}
+}
+
+func TestCron_ResyncBackward(t *testing.T) {
+	c := New(WithSecond())
+	id, err := c.AddJob("* * * * * *", func() {})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	job := c.jobs[id-1]
+	for i := 0; i < 3600; i++ {
+		_, _ = job.clock.NextWithWeek()
+	}
+	job.nextTime = job.clock.Now()
+
+	now := time.Now().Truncate(time.Second)
+	c.resync(now, false)
+	if job.nextTime.Sub(now) > time.Second*2 {
+		t.Error("next time", job.nextTime)
+	}
+}
+
+func TestCron_TickYearRollover(t *testing.T) {
+	now, _ := time.ParseInLocation(time.DateTime, "2023-12-31 23:58:00", time.Local)
+	clock := &fakeNow{now: now}
+	c := New(WithNowFunc(clock.Now))
+	var newYear, lastMinute, everyMinute atomic.Int32
+	c.MustAddJob("0 0 1 1 *", func() {
+		newYear.Add(1)
+	})
+	c.MustAddJob("59 23 31 12 *", func() {
+		lastMinute.Add(1)
+	})
+	c.MustAddJob("every 1 minute", func() {
+		everyMinute.Add(1)
+	})
+	for _, job := range c.jobs {
+		if err := job.Next(c.interval); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	for i := 0; i < 5; i++ {
+		c.tick(SlotSinceEpoch(now, c.interval))
+		now = clock.Add(time.Minute)
+	}
+	time.Sleep(time.Millisecond * 10)
+	if newYear.Load() != 1 || lastMinute.Load() != 1 || everyMinute.Load() != 4 {
+		t.Error("runs", newYear.Load(), lastMinute.Load(), everyMinute.Load())
+	}
+	if next := c.jobs[0].nextTime; next.Year() != 2025 {
+		t.Error("next new year", next)
+	}
+}
+
+func TestCron_TickLeapDay(t *testing.T) {
+	now, _ := time.ParseInLocation(time.DateTime, "2024-02-28 23:59:00", time.Local)
+	clock := &fakeNow{now: now}
+	c := New(WithNowFunc(clock.Now))
+	var runs atomic.Int32
+	id := c.MustAddJob("0 0 29 2 *", func() {
+		runs.Add(1)
+	})
+	job := c.jobs[id-1]
+	if err := job.Next(c.interval); err != nil {
+		t.Error(err)
+		return
+	}
+
+	for i := 0; i < 3; i++ {
+		c.tick(SlotSinceEpoch(now, c.interval))
+		now = clock.Add(time.Minute)
+	}
+	time.Sleep(time.Millisecond * 10)
+	if runs.Load() != 1 {
+		t.Error("runs", runs.Load())
+	}
+	if job.nextTime.Format(time.DateOnly) != "2028-02-29" {
+		t.Error("next", job.nextTime)
+	}
+}
+
+func TestCron_Shutdown(t *testing.T) {
+	c := New(WithSecond())
+	var done atomic.Bool
+	id := c.MustAddJob("every 1 minute", func() {
+		time.Sleep(time.Millisecond * 50)
+		done.Store(true)
+	})
+	c.MustStart()
+	c.dispatch(c.jobs[id-1], time.Now())
+	if err := c.Shutdown(context.Background()); err != nil {
+		t.Error(err)
+	}
+	if !done.Load() {
+		t.Error("shutdown before the job returned")
+	}
+}
+
+func TestCron_ShutdownDeadline(t *testing.T) {
+	c := New(WithSecond())
+	cancelled := make(chan struct{})
+	id := c.MustAddContextJob("every 1 minute", func(ctx context.Context) error {
+		<-ctx.Done()
+		close(cancelled)
+		return ctx.Err()
+	})
+	c.MustStart()
+	c.dispatch(c.jobs[id-1], time.Now())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+	err := c.Shutdown(ctx)
+	var shutdownErr *ShutdownError
+	if !errors.As(err, &shutdownErr) || len(shutdownErr.Running) != 1 || shutdownErr.Running[0] != id {
+		t.Error(err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error(err)
+	}
+	<-cancelled
+}
+
+func TestCron_State(t *testing.T) {
+	c := New(WithSecond())
+	if c.State() != StateCreated {
+		t.Error(c.State())
+	}
+	c.MustStart()
+	if c.State() != StateRunning {
+		t.Error(c.State())
+	}
+	c.MustStop()
+	if c.State() != StateStopped {
+		t.Error(c.State())
+	}
+}
+
+func TestCron_RestartRuns(t *testing.T) {
+	var runs atomic.Int32
+	c := New(WithSecond())
+	c.MustAddJob("every 1 second", func() {
+		runs.Add(1)
+	})
+	c.MustStart()
+	c.MustStop()
+	c.MustRestart()
+	time.Sleep(time.Millisecond * 2100)
+	c.MustStop()
+	if runs.Load() == 0 {
+		t.Error("no run after restart")
+	}
+}
+
+func TestCron_StartStopConcurrent(t *testing.T) {
+	c := New(WithSecond())
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			_ = c.Start()
+		}()
+		go func() {
+			defer wg.Done()
+			_ = c.Stop()
+		}()
+		go func() {
+			defer wg.Done()
+			_ = c.Restart()
+		}()
+	}
+	wg.Wait()
+	_ = c.Stop()
+	if c.State() != StateStopped {
+		t.Error(c.State())
+	}
+}
+
+func TestCron_Run(t *testing.T) {
+	c := New(WithSecond())
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+	if err := c.Run(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Error(err)
+	}
+	if c.State() != StateStopped {
+		t.Error(c.State())
+	}
+}
+
+// fakeNow is a time source for WithNowFunc moved by hand.
+type fakeNow struct {
+	locker sync.Mutex
+	now    time.Time
+}
+
+func (f *fakeNow) Now() time.Time {
+	f.locker.Lock()
+	defer f.locker.Unlock()
+	return f.now
+}
+
+func (f *fakeNow) Add(d time.Duration) time.Time {
+	f.locker.Lock()
+	defer f.locker.Unlock()
+	f.now = f.now.Add(d)
+	return f.now
+}
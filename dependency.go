@@ -0,0 +1,217 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+)
+
+// Trigger is the outcome of the upstream jobs a dependent job runs after.
+type Trigger uint8
+
+const (
+	// TriggerSuccess runs after the upstream succeeded.
+	TriggerSuccess Trigger = iota
+	// TriggerFailure runs after the upstream failed, after its last retry.
+	TriggerFailure
+	// TriggerAny runs after the upstream finished either way.
+	TriggerAny
+)
+
+func (t Trigger) String() string {
+	switch t {
+	case TriggerSuccess:
+		return "success"
+	case TriggerFailure:
+		return "failure"
+	case TriggerAny:
+		return "any"
+	default:
+		return "unknown"
+	}
+}
+
+func (t Trigger) match(err error) bool {
+	switch t {
+	case TriggerSuccess:
+		return err == nil
+	case TriggerFailure:
+		return err != nil
+	default:
+		return true
+	}
+}
+
+// dependency is what a dependent job waits for. It runs once every upstream
+// finished with the trigger since its last run.
+type dependency struct {
+	on        Trigger
+	satisfied map[uint32]bool
+}
+
+func newCorrelationID() string {
+	return fmt.Sprintf("%016x", rand.Uint64())
+}
+
+func (c *Cron) MustDependOn(id uint32, on Trigger, upstream ...uint32) {
+	_ = c.DependOn(id, on, upstream...)
+}
+
+// DependOn makes the job run after all the upstream jobs finished with the
+// trigger, it replaces the former dependencies of the job. A dependency that
+// closes a cycle is refused.
+func (c *Cron) DependOn(id uint32, on Trigger, upstream ...uint32) (err error) {
+	if c == nil {
+		err = errors.New("cron nil")
+		c.logger.Error(err)
+		return
+	}
+
+	c.locker.Lock()
+	defer c.unlock()
+
+	job, err := c.lookup(id)
+	if err == nil && job.Deleted {
+		err = errors.New("job removed")
+	}
+	if err == nil {
+		err = c.checkUpstream(id, upstream)
+	}
+	if err != nil {
+		c.logger.Error(err)
+		return
+	}
+
+	c.undepend(job)
+	c.depend(job, on, upstream)
+	c.logger.Info("job depends on:", id, upstream)
+	return
+}
+
+// checkUpstream validates the upstream jobs of the job, the caller holds
+// c.locker. Upstream jobs reachable from the job would close a cycle.
+func (c *Cron) checkUpstream(id uint32, upstream []uint32) (err error) {
+	c.dependLocker.Lock()
+	defer c.dependLocker.Unlock()
+
+	for _, v := range upstream {
+		job, e := c.lookup(v)
+		if e != nil {
+			err = e
+			return
+		}
+		if job.Deleted {
+			err = errors.New("upstream job removed")
+			return
+		}
+		if v == id || c.reaches(id, v, map[uint32]bool{}) {
+			err = errors.New("dependency cycle")
+			return
+		}
+	}
+	return
+}
+
+// reaches reports whether to runs after from, the caller holds c.dependLocker.
+func (c *Cron) reaches(from uint32, to uint32, seen map[uint32]bool) bool {
+	if seen[from] {
+		return false
+	}
+	seen[from] = true
+	for _, job := range c.dependents[from] {
+		if job.id == to || c.reaches(job.id, to, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// depend links the job to its upstream jobs, the caller holds c.locker.
+func (c *Cron) depend(job *Job, on Trigger, upstream []uint32) {
+	c.dependLocker.Lock()
+	defer c.dependLocker.Unlock()
+
+	job.depends = &dependency{
+		on:        on,
+		satisfied: make(map[uint32]bool),
+	}
+	for _, v := range upstream {
+		if _, ok := job.depends.satisfied[v]; ok {
+			continue
+		}
+		job.depends.satisfied[v] = false
+		c.dependents[v] = append(c.dependents[v], job)
+	}
+}
+
+// undepend unlinks the job from its upstream jobs and its dependent jobs, a
+// dependent job no longer waits for a removed job.
+func (c *Cron) undepend(job *Job) {
+	c.dependLocker.Lock()
+	defer c.dependLocker.Unlock()
+
+	if job.depends != nil {
+		for v := range job.depends.satisfied {
+			dependents := c.dependents[v][:0]
+			for _, d := range c.dependents[v] {
+				if d != job {
+					dependents = append(dependents, d)
+				}
+			}
+			c.dependents[v] = dependents
+		}
+		job.depends = nil
+	}
+
+	for _, d := range c.dependents[job.id] {
+		delete(d.depends.satisfied, job.id)
+	}
+	delete(c.dependents, job.id)
+}
+
+// trigger records the outcome of a run for the dependent jobs, the ones
+// ready run on the next tick with the correlation of the run.
+func (c *Cron) trigger(job *Job, info JobInfo, err error) {
+	c.dependLocker.Lock()
+	defer c.dependLocker.Unlock()
+
+	for _, d := range c.dependents[job.id] {
+		if !d.depends.on.match(err) {
+			continue
+		}
+		d.depends.satisfied[job.id] = true
+		ready := true
+		for _, ok := range d.depends.satisfied {
+			ready = ready && ok
+		}
+		if !ready {
+			continue
+		}
+		for v := range d.depends.satisfied {
+			d.depends.satisfied[v] = false
+		}
+		c.triggers = append(c.triggers, JobInfo{
+			ID:            d.id,
+			Scheduled:     c.nowFunc(),
+			CorrelationID: info.CorrelationID,
+		})
+	}
+}
+
+// fireTriggers dispatches the dependent jobs triggered since the last tick,
+// the caller holds c.locker.
+func (c *Cron) fireTriggers() {
+	c.dependLocker.Lock()
+	triggers := c.triggers
+	c.triggers = nil
+	c.dependLocker.Unlock()
+
+	for _, info := range triggers {
+		job, err := c.lookup(info.ID)
+		if err != nil || job.Deleted || job.paused || job.completed {
+			continue
+		}
+		c.logger.Info("job triggered:", info.ID, info.CorrelationID)
+		c.dispatchRun(job, info)
+	}
+}
@@ -0,0 +1,115 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCron_DependOn(t *testing.T) {
+	c := New()
+	var locker sync.Mutex
+	var correlations []string
+	record := func(ctx context.Context) {
+		info, _ := JobInfoFromContext(ctx)
+		locker.Lock()
+		correlations = append(correlations, info.CorrelationID)
+		locker.Unlock()
+	}
+	importJob := c.MustAddContextJob("@manual", func(ctx context.Context) error {
+		record(ctx)
+		return nil
+	})
+	transform := c.MustAddContextJob("@manual", func(ctx context.Context) error {
+		record(ctx)
+		return errors.New("transform failed")
+	}, JobDependsOn(TriggerSuccess, importJob))
+	alert := c.MustAddContextJob("@manual", func(ctx context.Context) error {
+		record(ctx)
+		return nil
+	}, JobDependsOn(TriggerFailure, transform))
+	c.MustAddContextJob("@manual", func(ctx context.Context) error {
+		record(ctx)
+		return nil
+	}, JobDependsOn(TriggerSuccess, transform))
+
+	c.dispatch(c.jobs[importJob-1], time.Now())
+	for i := 0; i < 3; i++ {
+		time.Sleep(time.Millisecond * 10)
+		c.locker.Lock()
+		c.fireTriggers()
+		c.locker.Unlock()
+	}
+	time.Sleep(time.Millisecond * 10)
+
+	locker.Lock()
+	defer locker.Unlock()
+	if len(correlations) != 3 {
+		t.Error("runs", len(correlations))
+		return
+	}
+	for _, v := range correlations {
+		if v == "" || v != correlations[0] {
+			t.Error("correlation", correlations)
+		}
+	}
+	if s, _ := c.GetJob(alert); s.Runs != 1 {
+		t.Error("alert runs", s.Runs)
+	}
+}
+
+func TestCron_DependOnCycle(t *testing.T) {
+	c := New()
+	a := c.MustAddJob("@manual", func() {})
+	b := c.MustAddJob("@manual", func() {}, JobDependsOn(TriggerAny, a))
+	d := c.MustAddJob("@manual", func() {}, JobDependsOn(TriggerAny, b))
+	if err := c.DependOn(a, TriggerAny, d); err == nil {
+		t.Error("expected err, cycle")
+	}
+	if err := c.DependOn(a, TriggerAny, a); err == nil {
+		t.Error("expected err, self")
+	}
+	if _, err := c.AddJob("@manual", func() {}, JobDependsOn(TriggerAny, 10)); err == nil {
+		t.Error("expected err, not exists")
+	}
+	if err := c.DependOn(d, TriggerAny, a); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCron_DependOnFanIn(t *testing.T) {
+	c := New()
+	a := c.MustAddJob("@manual", func() {})
+	b := c.MustAddJob("@manual", func() {})
+	ran := make(chan struct{}, 2)
+	c.MustAddJob("@manual", func() {
+		ran <- struct{}{}
+	}, JobDependsOn(TriggerSuccess, a, b))
+
+	step := func(id uint32) {
+		c.dispatch(c.jobs[id-1], time.Now())
+		time.Sleep(time.Millisecond * 10)
+		c.locker.Lock()
+		c.fireTriggers()
+		c.locker.Unlock()
+		time.Sleep(time.Millisecond * 10)
+	}
+	step(a)
+	step(a)
+	if len(ran) != 0 {
+		t.Error("ran before b")
+	}
+	step(b)
+	if len(ran) != 1 {
+		t.Error("runs", len(ran))
+	}
+
+	// a removed upstream is no longer waited for
+	c.MustRemoveJob(b)
+	step(a)
+	if len(ran) != 2 {
+		t.Error("runs", len(ran))
+	}
+}
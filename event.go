@@ -0,0 +1,174 @@
+package cron
+
+import (
+	"time"
+)
+
+type EventType uint8
+
+const (
+	EventJobAdded EventType = iota
+	EventJobRemoved
+	EventJobScheduled
+	EventJobStarted
+	EventJobSucceeded
+	EventJobFailed
+	EventJobPanicked
+	EventJobSkipped
+	EventJobMisfired
+	EventCronStarted
+	EventCronStopped
+	EventJobPaused
+	EventJobResumed
+	EventCronPaused
+	EventCronResumed
+	EventJobCompleted
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventJobAdded:
+		return "job added"
+	case EventJobRemoved:
+		return "job removed"
+	case EventJobScheduled:
+		return "job scheduled"
+	case EventJobStarted:
+		return "job started"
+	case EventJobSucceeded:
+		return "job succeeded"
+	case EventJobFailed:
+		return "job failed"
+	case EventJobPanicked:
+		return "job panicked"
+	case EventJobSkipped:
+		return "job skipped"
+	case EventJobMisfired:
+		return "job misfired"
+	case EventCronStarted:
+		return "cron started"
+	case EventCronStopped:
+		return "cron stopped"
+	case EventJobPaused:
+		return "job paused"
+	case EventJobResumed:
+		return "job resumed"
+	case EventCronPaused:
+		return "cron paused"
+	case EventCronResumed:
+		return "cron resumed"
+	case EventJobCompleted:
+		return "job completed"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is something that happened to the cron or one of its jobs, the job
+// fields are zero for cron events.
+type Event struct {
+	Type      EventType
+	Time      time.Time
+	JobID     uint32
+	Name      string
+	Scheduled time.Time
+	Next      time.Time
+	Attempt   int
+	// CorrelationID is the correlation of the run, see JobInfo.
+	CorrelationID string
+	// Delay is how long the rate limit held the run back.
+	Delay time.Duration
+	// Missed is the number of runs a misfire missed.
+	Missed int
+	Err    error
+}
+
+// Listener is called synchronously for every event, so it must return quickly.
+// It is never called with the cron locked, so it may call the cron.
+type Listener func(Event)
+
+// AddListener registers a listener for the events.
+func (c *Cron) AddListener(listener Listener) {
+	c.listenerLocker.Lock()
+	defer c.listenerLocker.Unlock()
+
+	c.listeners = append(c.listeners, listener)
+}
+
+// Events returns a channel of the events, buffered by WithEventBuffer. When
+// the buffer is full new events are dropped rather than blocking the wheel,
+// DroppedEvents counts them. Events are only sent after the first call.
+func (c *Cron) Events() <-chan Event {
+	c.subscribed.Store(true)
+	return c.events
+}
+
+// DroppedEvents returns how many events the full Events channel dropped.
+func (c *Cron) DroppedEvents() uint64 {
+	return c.droppedEvents.Load()
+}
+
+func (c *Cron) emit(event Event) {
+	if event.Time.IsZero() {
+		event.Time = c.nowFunc()
+	}
+
+	c.listenerLocker.RLock()
+	listeners := c.listeners
+	c.listenerLocker.RUnlock()
+	for _, listener := range listeners {
+		listener(event)
+	}
+
+	if !c.subscribed.Load() {
+		return
+	}
+	select {
+	case c.events <- event:
+	default:
+		c.droppedEvents.Add(1)
+	}
+}
+
+// post queues the event until unlock releases c.locker, so the listeners can
+// call the cron. The caller holds c.locker.
+func (c *Cron) post(event Event) {
+	if event.Time.IsZero() {
+		event.Time = c.nowFunc()
+	}
+	c.posted = append(c.posted, event)
+}
+
+// postScheduled posts the next time of the job.
+func (c *Cron) postScheduled(job *Job) {
+	c.post(Event{
+		Type:  EventJobScheduled,
+		JobID: job.id,
+		Name:  job.name,
+		Next:  job.nextTime,
+	})
+}
+
+// emitJob emits an event about a run of the job.
+func (c *Cron) emitJob(t EventType, job *Job, info JobInfo, err error) {
+	c.emit(jobEvent(t, job, info, err))
+}
+
+// postJob posts an event about a run of the job, see post.
+func (c *Cron) postJob(t EventType, job *Job, info JobInfo, err error) {
+	c.post(jobEvent(t, job, info, err))
+}
+
+func jobEvent(t EventType, job *Job, info JobInfo, err error) Event {
+	return Event{
+		Type:      t,
+		JobID:     job.id,
+		Name:      job.name,
+		Scheduled: info.Scheduled,
+		Attempt:   info.Attempt,
+		Err:       err,
+
+		CorrelationID: info.CorrelationID,
+		Delay:         info.Delay,
+	}
+}
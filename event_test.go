@@ -0,0 +1,136 @@
+package cron
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCron_AddListener(t *testing.T) {
+	var locker sync.Mutex
+	var types []EventType
+	c := New(WithListener(func(event Event) {
+		locker.Lock()
+		defer locker.Unlock()
+		types = append(types, event.Type)
+	}), WithSecond())
+	id := c.MustAddJob("every 1 minute", func() {
+		panic("boom")
+	}, JobOverlap(OverlapSkip))
+	job := c.jobs[id-1]
+	c.dispatch(job, time.Now())
+	c.wg.Wait()
+
+	release := make(chan struct{})
+	id = c.MustAddJob("every 1 minute", func() {
+		<-release
+	}, JobOverlap(OverlapSkip))
+	job = c.jobs[id-1]
+	c.dispatch(job, time.Now())
+	c.dispatch(job, time.Now())
+	close(release)
+	c.wg.Wait()
+	c.MustRemoveJob(id)
+
+	count := map[EventType]int{}
+	locker.Lock()
+	for _, v := range types {
+		count[v]++
+	}
+	locker.Unlock()
+	for eventType, n := range map[EventType]int{
+		EventJobAdded:     2,
+		EventJobStarted:   2,
+		EventJobPanicked:  1,
+		EventJobSkipped:   1,
+		EventJobSucceeded: 1,
+		EventJobRemoved:   1,
+	} {
+		if count[eventType] != n {
+			t.Error(eventType, count[eventType])
+		}
+	}
+}
+
+func TestCron_Events(t *testing.T) {
+	c := New(WithEventBuffer(1))
+	c.MustAddJob("every 1 minute", func() {})
+	events := c.Events()
+	c.MustAddJob("every 1 minute", func() {})
+	c.MustAddJob("every 1 minute", func() {})
+
+	event := <-events
+	if event.Type != EventJobAdded || event.JobID != 2 {
+		t.Errorf("%+v", event)
+	}
+	if c.DroppedEvents() != 1 {
+		t.Error("dropped", c.DroppedEvents())
+	}
+}
+
+func TestCron_ListenerCallsCron(t *testing.T) {
+	c := New()
+	var names []string
+	c.AddListener(func(event Event) {
+		if event.Type != EventJobAdded && event.Type != EventJobRemoved {
+			return
+		}
+		// the events are delivered after the cron is unlocked
+		jobs := c.ListJobs()
+		names = append(names, fmt.Sprintf("%s %d", event.Type, len(jobs)))
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		id := c.MustAddJob("every 1 minute", func() {})
+		c.MustRemoveJob(id)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("deadlock")
+	}
+	if len(names) != 2 || names[0] != "job added 1" || names[1] != "job removed 0" {
+		t.Error(names)
+	}
+}
+
+func TestCron_ListenerCallsLifecycle(t *testing.T) {
+	c := New()
+	ran := make(chan struct{}, 1)
+	id := c.MustAddJob("@manual", func() {
+		ran <- struct{}{}
+	})
+	errs := make(chan error, 2)
+	c.AddListener(func(event Event) {
+		switch event.Type {
+		case EventCronStarted:
+			_, err := c.RunNow(id)
+			errs <- err
+		case EventCronStopped:
+			errs <- c.Stop()
+		}
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.MustStart()
+		<-ran
+		c.MustStop()
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("deadlock")
+	}
+	if err := <-errs; err != nil {
+		t.Error("run now", err)
+	}
+	// the cron is already stopped
+	if err := <-errs; err == nil {
+		t.Error("expected err")
+	}
+}
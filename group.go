@@ -0,0 +1,111 @@
+package cron
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrGroupSaturated is the error of the skipped event of a run its
+// concurrency group had no room for.
+var ErrGroupSaturated = errors.New("concurrency group saturated")
+
+// GroupPolicy decides what a run does when its concurrency group is full.
+type GroupPolicy uint8
+
+const (
+	// GroupWait holds the run back until a run of the group ends, the default.
+	GroupWait GroupPolicy = iota
+	// GroupSkip skips the run.
+	GroupSkip
+)
+
+func (p GroupPolicy) String() string {
+	switch p {
+	case GroupWait:
+		return "wait"
+	case GroupSkip:
+		return "skip"
+	default:
+		return "unknown"
+	}
+}
+
+// group limits the runs in flight of the jobs sharing it. The runs it has no
+// room for are held back before they reach a worker.
+type group struct {
+	name     string
+	policy   GroupPolicy
+	limit    int
+	locker   sync.Mutex
+	inFlight int
+	waiting  []*task
+}
+
+func newGroup(name string, limit int, policy GroupPolicy) *group {
+	if limit < 1 {
+		limit = 1
+	}
+	return &group{
+		name:   name,
+		policy: policy,
+		limit:  limit,
+	}
+}
+
+// admit takes a place in the group for the task. When the group is full,
+// held is true if the task waits for release, otherwise it is skipped. A job
+// without group always gets one.
+func (g *group) admit(t *task) (ok bool, held bool) {
+	if g == nil {
+		return true, false
+	}
+
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if g.inFlight < g.limit {
+		g.inFlight++
+		return true, false
+	}
+	if g.policy == GroupWait {
+		g.waiting = append(g.waiting, t)
+		return false, true
+	}
+	return
+}
+
+// release gives up a place in the group, it hands it to the first task held
+// back if any.
+func (g *group) release() (next *task) {
+	if g == nil {
+		return
+	}
+
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	if len(g.waiting) == 0 {
+		g.inFlight--
+		return
+	}
+	next = g.waiting[0]
+	g.waiting[0] = nil
+	g.waiting = g.waiting[1:]
+	return
+}
+
+// InFlight returns how many runs of the group are running.
+func (c *Cron) InFlight(name string) (n int, err error) {
+	g, ok := c.groups[name]
+	if !ok {
+		err = errors.New("group not exists")
+		c.logger.Error(err)
+		return
+	}
+
+	g.locker.Lock()
+	defer g.locker.Unlock()
+
+	n = g.inFlight
+	return
+}
@@ -0,0 +1,117 @@
+package cron
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestJobGroup(t *testing.T) {
+	var running, max, runs atomic.Int32
+	c := New(WithGroup("db", 2, GroupWait))
+	var ids []uint32
+	for i := 0; i < 3; i++ {
+		ids = append(ids, c.MustAddJob("every 1 minute", func() {
+			n := running.Add(1)
+			for m := max.Load(); n > m && !max.CompareAndSwap(m, n); m = max.Load() {
+			}
+			time.Sleep(time.Millisecond * 5)
+			running.Add(-1)
+			runs.Add(1)
+		}, JobGroup("db")))
+	}
+	for i := 0; i < 2; i++ {
+		for _, id := range ids {
+			c.dispatch(c.jobs[id-1], time.Now())
+		}
+	}
+	c.wg.Wait()
+
+	if runs.Load() != 6 || max.Load() > 2 {
+		t.Error("runs", runs.Load(), "max concurrency", max.Load())
+	}
+	if n, _ := c.InFlight("db"); n != 0 {
+		t.Error("in flight", n)
+	}
+}
+
+func TestJobGroupSkip(t *testing.T) {
+	c := New(WithGroup("db", 1, GroupSkip))
+	var skipped atomic.Int32
+	c.AddListener(func(event Event) {
+		if event.Type == EventJobSkipped && errors.Is(event.Err, ErrGroupSaturated) {
+			skipped.Add(1)
+		}
+	})
+	started := make(chan struct{})
+	release := make(chan struct{})
+	blocker := c.MustAddJob("every 1 minute", func() {
+		close(started)
+		<-release
+	}, JobGroup("db"))
+	id := c.MustAddJob("every 1 minute", func() {}, JobGroup("db"))
+
+	c.dispatch(c.jobs[blocker-1], time.Now())
+	<-started
+	c.dispatch(c.jobs[id-1], time.Now())
+	time.Sleep(time.Millisecond * 10)
+	close(release)
+	c.wg.Wait()
+
+	if skipped.Load() != 1 {
+		t.Error("skipped", skipped.Load())
+	}
+	if s, _ := c.GetJob(id); s.Runs != 0 {
+		t.Error("runs", s.Runs)
+	}
+
+	if _, err := c.AddJob("every 1 minute", func() {}, JobGroup("cache")); err == nil {
+		t.Error("expected err")
+	}
+}
+
+func TestJobGroupWorkerPool(t *testing.T) {
+	c := New(WithWorkerPool(2, 10), WithGroup("db", 1, GroupWait))
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var runs atomic.Int32
+	blocker := c.MustAddJob("every 1 minute", func() {
+		close(started)
+		<-release
+	}, JobGroup("db"))
+	waiting := c.MustAddJob("every 1 minute", func() {
+		runs.Add(1)
+	}, JobGroup("db"))
+	ran := make(chan struct{})
+	free := c.MustAddJob("every 1 minute", func() {
+		close(ran)
+	})
+
+	c.locker.Lock()
+	c.dispatch(c.jobs[blocker-1], time.Now())
+	c.unlock()
+	<-started
+	c.locker.Lock()
+	c.dispatch(c.jobs[waiting-1], time.Now())
+	c.dispatch(c.jobs[free-1], time.Now())
+	c.unlock()
+
+	// the held back run does not take the second worker
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Error("free job starved")
+	}
+	if runs.Load() != 0 {
+		t.Error("runs while the group is full", runs.Load())
+	}
+	close(release)
+	c.wg.Wait()
+	if runs.Load() != 1 {
+		t.Error("runs", runs.Load())
+	}
+	if n, _ := c.InFlight("db"); n != 0 {
+		t.Error("in flight", n)
+	}
+}
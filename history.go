@@ -0,0 +1,109 @@
+package cron
+
+import (
+	"errors"
+	"time"
+)
+
+// defaultHistorySize is how many runs a job keeps without WithHistorySize.
+const defaultHistorySize = 10
+
+// Outcome is how a run ended.
+type Outcome uint8
+
+const (
+	OutcomeSucceeded Outcome = iota
+	OutcomeFailed
+	OutcomePanicked
+	OutcomeTimedOut
+	OutcomeSkipped
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case OutcomeSucceeded:
+		return "succeeded"
+	case OutcomeFailed:
+		return "failed"
+	case OutcomePanicked:
+		return "panicked"
+	case OutcomeTimedOut:
+		return "timed out"
+	case OutcomeSkipped:
+		return "skipped"
+	default:
+		return "unknown"
+	}
+}
+
+// RunRecord is one run of a job in its history.
+type RunRecord struct {
+	Scheduled  time.Time
+	Start      time.Time
+	End        time.Time
+	Duration   time.Duration
+	Outcome    Outcome
+	Err        string
+	PanicStack string
+	Attempt    int
+	// Delay is how long the rate limit held the run back.
+	Delay         time.Duration
+	CorrelationID string
+}
+
+// history is a ring buffer of the last runs of a job.
+type history struct {
+	records []RunRecord
+	next    int
+}
+
+func (h *history) add(record RunRecord, size int) {
+	if size <= 0 {
+		return
+	}
+	if len(h.records) < size {
+		h.records = append(h.records, record)
+		return
+	}
+	h.records[h.next] = record
+	h.next = (h.next + 1) % len(h.records)
+}
+
+// list copies the records, oldest first.
+func (h *history) list() (records []RunRecord) {
+	records = make([]RunRecord, 0, len(h.records))
+	records = append(records, h.records[h.next:]...)
+	records = append(records, h.records[:h.next]...)
+	return
+}
+
+// record adds the run to the history of the job.
+func (j *Job) record(record RunRecord) {
+	j.locker.Lock()
+	defer j.locker.Unlock()
+
+	j.history.add(record, j.historySize)
+}
+
+// History returns the last runs of the job, oldest first, see WithHistorySize.
+func (c *Cron) History(id uint32) (records []RunRecord, err error) {
+	if c == nil {
+		err = errors.New("cron nil")
+		c.logger.Error(err)
+		return
+	}
+
+	c.locker.Lock()
+	job, err := c.lookup(id)
+	c.unlock()
+	if err != nil {
+		c.logger.Error(err)
+		return
+	}
+
+	job.locker.Lock()
+	defer job.locker.Unlock()
+
+	records = job.history.list()
+	return
+}
@@ -0,0 +1,104 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHistory_Ring(t *testing.T) {
+	var h history
+	for i := 1; i <= 5; i++ {
+		h.add(RunRecord{Attempt: i}, 3)
+	}
+	records := h.list()
+	if len(records) != 3 || records[0].Attempt != 3 || records[2].Attempt != 5 {
+		t.Error("records", records)
+	}
+}
+
+func TestCron_History(t *testing.T) {
+	c := New(WithHistorySize(3))
+	runs := 0
+	id := c.MustAddContextJob("every 1 minute", func(ctx context.Context) error {
+		runs++
+		switch runs {
+		case 1:
+			return errors.New("failed")
+		case 2:
+			panic("boom")
+		case 3:
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	}, JobTimeout(time.Millisecond*10))
+	job := c.jobs[id-1]
+	scheduled := time.Now().Truncate(time.Minute)
+	for i := 0; i < 4; i++ {
+		_ = c.runJob(job, job.newRun(c.ctx, JobInfo{Scheduled: scheduled, CorrelationID: "run"}))
+	}
+
+	records, err := c.History(id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(records) != 3 {
+		t.Error("records", len(records))
+		return
+	}
+	if records[0].Outcome != OutcomePanicked || records[0].PanicStack == "" || records[0].Err != "panic: boom" {
+		t.Errorf("%+v", records[0])
+	}
+	if records[1].Outcome != OutcomeTimedOut || records[1].Duration < time.Millisecond*10 {
+		t.Errorf("%+v", records[1])
+	}
+	if records[2].Outcome != OutcomeSucceeded || records[2].Err != "" {
+		t.Errorf("%+v", records[2])
+	}
+	for _, v := range records {
+		if !v.Scheduled.Equal(scheduled) || v.Attempt != 1 || v.CorrelationID != "run" || v.End.Before(v.Start) {
+			t.Errorf("%+v", v)
+		}
+	}
+}
+
+func TestCron_HistoryWrappers(t *testing.T) {
+	c := New(WithChain(Recover(&LoggerNothing{}), Timeout(time.Millisecond*10)))
+	var panicked atomic.Int32
+	c.AddListener(func(event Event) {
+		if event.Type == EventJobPanicked {
+			panicked.Add(1)
+		}
+	})
+	boom := c.MustAddJob("@manual", func() {
+		panic("boom")
+	})
+	slow := c.MustAddContextJob("@manual", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	c.dispatch(c.jobs[boom-1], time.Now())
+	c.dispatch(c.jobs[slow-1], time.Now())
+	c.wg.Wait()
+
+	// the wrappers do not hide the panic or the timeout
+	records, _ := c.History(boom)
+	if len(records) != 1 || records[0].Outcome != OutcomePanicked || !strings.Contains(records[0].PanicStack, "goroutine") {
+		t.Error("panic", records)
+	}
+	if panicked.Load() != 1 {
+		t.Error("panicked events", panicked.Load())
+	}
+	records, _ = c.History(slow)
+	if len(records) != 1 || records[0].Outcome != OutcomeTimedOut {
+		t.Error("timeout", records)
+	}
+	if s, _ := c.GetJob(slow); s.Timeouts != 1 {
+		t.Error("timeouts", s.Timeouts)
+	}
+}
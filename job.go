@@ -1,15 +1,32 @@
 package cron
 
 import (
+	"context"
 	"errors"
+	"hash/fnv"
+	"math"
+	"math/rand"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type Callback func()
 
+// ContextCallback is a callback that can watch ctx for the cron stopping,
+// the job being removed or timing out.
+type ContextCallback func(ctx context.Context) error
+
+func (f Callback) context() ContextCallback {
+	return func(context.Context) error {
+		f()
+		return nil
+	}
+}
+
 type EveryType uint8
 
 const (
@@ -24,6 +41,7 @@ const (
 var reEvery = regexp.MustCompile(`every\s(\d+)\s(second|minute|hour|day|month|week)s?`)
 var reDash = regexp.MustCompile(`(\d+)-(\d+)`)
 var reSlash = regexp.MustCompile(`\*/(\d+)`)
+var reHash = regexp.MustCompile(`^H(?:\((\d+)-(\d+)\))?(?:/(\d+))?$`)
 
 type element struct {
 	min  int
@@ -41,30 +59,131 @@ var parser = []element{
 }
 
 type Job struct {
-	Callback   Callback
-	Deleted    bool
-	slot       uint32
-	nextTime   time.Time
-	clock      *Clock
-	everyType  EveryType
-	everyValue uint8
+	Callback    Callback
+	Deleted     bool
+	paused      bool
+	callback    ContextCallback
+	wrapped     ContextCallback
+	chain       []JobWrapper
+	id          uint32
+	name        string
+	description string
+	tags        []string
+	spec        string
+	jitter      time.Duration
+	slot        uint64
+	nextTime    time.Time
+	nowFunc     func() time.Time
+	clock       *Clock
+	solar       *solar
+	everyType   EveryType
+	everyValue  uint8
+
+	misfirePolicy MisfirePolicy
+	misfireLimit  int
+	misfireGrace  time.Duration
+	missed        int
+	missedAt      time.Time
+	// missedTimes are the runs MisfireFireAll dispatches
+	missedTimes []time.Time
+
+	timeout      time.Duration
+	timeoutGrace time.Duration
+	timeouts     atomic.Uint64
+	locker       sync.Mutex
+	runs         map[*run]struct{}
+
+	overlapPolicy OverlapPolicy
+	queueLimit    int
+	active        int
+	pending       []JobInfo
+	skipped       uint64
+
+	retry *RetryPolicy
+	fires atomic.Uint64
+
+	divisibility bool
+	location     *time.Location
+	startAt      time.Time
+	endAt        time.Time
+	maxRuns      uint64
+	immediate    bool
+	completed    bool
+	autoRemove   bool
+	onComplete   func(JobSnapshot)
+
+	historySize int
+	history     history
+	priority    int
+	groupName   string
+	group       *group
+
+	// manual jobs are not on the wheel, they run by RunNow or other jobs
+	manual   bool
+	trigger  Trigger
+	upstream []uint32
+	// depends is guarded by c.dependLocker
+	depends *dependency
+
+	prevTime time.Time
+	started  atomic.Uint64
+	failures atomic.Uint64
 }
 
+// Slot returns the slot of the next run, counted in intervals since the unix
+// epoch, which fits an uint32 until 2106.
 func (j *Job) Slot() uint32 {
-	return j.slot
+	return uint32(j.slot)
+}
+
+// start records the start of a run.
+func (j *Job) start(now time.Time) {
+	j.started.Add(1)
+
+	j.locker.Lock()
+	defer j.locker.Unlock()
+	j.prevTime = now
+}
+
+// in converts the time to the location of the job.
+func (j *Job) in(t time.Time) time.Time {
+	if j.location != nil {
+		return t.In(j.location)
+	}
+	return t
+}
+
+func (j *Job) timeNow() time.Time {
+	if j.nowFunc != nil {
+		return j.nowFunc()
+	}
+	return time.Now()
 }
 
 func (j *Job) Init(spec string, interval time.Duration, divisibility bool) (err error) {
-	now := time.Now()
+	j.spec = spec
+	now := j.timeNow()
 
 	if interval == time.Second {
 		now = time.Unix(now.Unix(), 0)
 	} else {
 		now = time.Unix(now.Unix()-int64(now.Second()), 0)
 	}
+	now = j.in(now)
 
 	r := reEvery.FindStringSubmatch(spec)
-	if len(r) == 3 {
+	if spec == "@manual" {
+		j.manual = true
+		j.nextTime = time.Time{}
+		return
+	} else if strings.HasPrefix(spec, "@") {
+		s, e := newSolar(spec)
+		if e != nil {
+			err = e
+			return
+		}
+		j.solar = s
+	} else if len(r) == 3 {
 		if strings.Index("second|minute|hour|day|month|week", r[2]) < 0 {
 			err = errors.New("parse err")
 			return
@@ -183,6 +302,13 @@ func (j *Job) Init(spec string, interval time.Duration, divisibility bool) (err
 					}
 					continue
 				}
+				r = reHash.FindStringSubmatch(v)
+				if len(r) == 4 {
+					if list[i], err = j.spread(i, r); err != nil {
+						break LOOP1
+					}
+					continue
+				}
 				r = reSlash.FindStringSubmatch(v)
 				if len(r) == 2 {
 					every, e := strconv.Atoi(r[1])
@@ -328,7 +454,7 @@ func (j *Job) Init(spec string, interval time.Duration, divisibility bool) (err
 				return
 			}
 
-			clock, e := NewClock(interval, list[0], list[1], list[2], list[3], list[4], list[5])
+			clock, e := newClock(now, interval, list[0], list[1], list[2], list[3], list[4], list[5])
 			if e != nil {
 				err = e
 				return
@@ -342,7 +468,7 @@ func (j *Job) Init(spec string, interval time.Duration, divisibility bool) (err
 	}
 
 	if interval == time.Minute {
-		now = time.Unix(now.Unix()-int64(now.Second()), 0)
+		now = j.in(time.Unix(now.Unix()-int64(now.Second()), 0))
 	}
 
 	j.nextTime = now
@@ -351,35 +477,195 @@ func (j *Job) Init(spec string, interval time.Duration, divisibility bool) (err
 }
 
 func (j *Job) Next(interval time.Duration) (err error) {
+	if j.maxRuns > 0 && j.fires.Load() >= j.maxRuns {
+		j.complete()
+		return
+	}
+
+	if j.manual {
+		return
+	}
+
 	now := j.nextTime
+	if now.Before(j.startAt) {
+		// jump to the start instead of walking there
+		if j.everyValue > 0 {
+			now = j.skip(now, j.startAt)
+		} else {
+			now = j.in(j.startAt.Add(-interval))
+			if j.clock != nil {
+				if err = j.clock.reset(now); err != nil {
+					return
+				}
+			}
+		}
+	}
+	for {
+		if now, err = j.after(now, interval); err != nil {
+			return
+		}
+		if !j.endAt.IsZero() && now.After(j.endAt) {
+			j.complete()
+			return
+		}
+		if now.Before(j.startAt) {
+			continue
+		}
+		if !now.Before(j.timeNow().Truncate(interval)) {
+			break
+		}
+		// missed, the scheduler applies the misfire policy
+		j.miss(now)
+	}
+
+	j.nextTime = now
+	j.slot = SlotSinceEpoch(now, interval)
+
+	if j.jitter >= interval {
+		// whole slots, before the following run so that it is not missed
+		slots := uint64(j.jitter / interval)
+		if following, err := j.following(now, interval); err == nil {
+			if gap := SlotSinceEpoch(following, interval) - j.slot; gap <= slots {
+				slots = gap - 1
+			}
+		}
+		j.slot += uint64(rand.Int63n(int64(slots) + 1))
+	}
+	return
+}
+
+// following returns the run after now without moving the job.
+func (j *Job) following(now time.Time, interval time.Duration) (time.Time, error) {
+	if j.everyValue == 0 && j.solar == nil {
+		clock := *j.clock
+		return clock.NextWithWeek()
+	}
+	return j.after(now, interval)
+}
+
+// complete takes the job off the wheel, it has no run left.
+func (j *Job) complete() {
+	j.completed = true
+	j.slot = 0
+}
+
+// reset moves the job back to now, used when the wall clock jumped backwards.
+func (j *Job) reset(now time.Time) (err error) {
+	now = j.in(now)
+	j.nextTime = now
+	if j.clock != nil {
+		err = j.clock.reset(now)
+	}
+	return
+}
+
+func (j *Job) after(now time.Time, interval time.Duration) (next time.Time, err error) {
 	if j.everyValue > 0 {
 		switch j.everyType {
 		case second:
-			now = now.Add(time.Second * time.Duration(j.everyValue))
+			next = now.Add(time.Second * time.Duration(j.everyValue))
 		case minute:
-			now = now.Add(time.Minute * time.Duration(j.everyValue))
+			next = now.Add(time.Minute * time.Duration(j.everyValue))
 		case hour:
-			now = now.Add(time.Hour * time.Duration(j.everyValue))
+			next = now.Add(time.Hour * time.Duration(j.everyValue))
 		case day:
-			now = now.AddDate(0, 0, int(j.everyValue))
+			next = now.AddDate(0, 0, int(j.everyValue))
 		case month:
-			now = now.AddDate(0, int(j.everyValue), 0)
+			next = now.AddDate(0, int(j.everyValue), 0)
 		case week:
-			now = now.AddDate(0, 0, 7*int(j.everyValue))
+			next = now.AddDate(0, 0, 7*int(j.everyValue))
 		}
-	} else {
-		now, err = j.clock.NextWithWeek()
-		if err != nil {
+		return
+	}
+
+	if j.solar != nil {
+		return j.solar.next(now, interval)
+	}
+
+	return j.clock.NextWithWeek()
+}
+
+// skip advances an every spec by whole steps to its last run before until,
+// Next walks the last steps. The runs keep their alignment to now.
+func (j *Job) skip(now time.Time, until time.Time) time.Time {
+	if !now.Before(until) {
+		return now
+	}
+	value := int(j.everyValue)
+	var step time.Duration
+	switch j.everyType {
+	case second:
+		step = time.Second
+	case minute:
+		step = time.Minute
+	case hour:
+		step = time.Hour
+	case day, week:
+		days := value
+		if j.everyType == week {
+			days *= 7
+		}
+		// a day is not always 24 hours, stop a step short
+		if n := int(until.Sub(now)/(24*time.Hour))/days - 1; n > 0 {
+			return now.AddDate(0, 0, n*days)
+		}
+	case month:
+		// the days past the 28th move with every step, walk those
+		if now.Day() <= 28 {
+			until = until.In(now.Location())
+			months := (until.Year()-now.Year())*12 + int(until.Month()) - int(now.Month())
+			if n := months/value - 1; n > 0 {
+				return now.AddDate(0, n*value, 0)
+			}
+		}
+	}
+	if step > 0 {
+		step *= time.Duration(value)
+		return now.Add((until.Sub(now) - 1) / step * step)
+	}
+	return now
+}
+
+// spread expands a Jenkins style "H" field, the hash of the job name picks a
+// stable value (or offset of "H/n") inside the range, so jobs sharing a spec
+// do not all fire in the same slot. An unnamed job hashes its spec and id.
+func (j *Job) spread(i int, r []string) (bits uint64, err error) {
+	begin := parser[i].min
+	end := parser[i].max
+	if i == 3 {
+		// days every month has
+		end = 28
+	}
+	if r[1] != "" {
+		begin, _ = strconv.Atoi(r[1])
+		end, _ = strconv.Atoi(r[2])
+		if begin < parser[i].min || end > parser[i].max || begin > end {
+			err = errors.New("parse err")
 			return
 		}
 	}
 
-	j.nextTime = now
+	seed := j.name
+	if seed == "" {
+		seed = j.spec + "#" + strconv.FormatUint(uint64(j.id), 10)
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(seed))
+	_, _ = h.Write([]byte{byte(i)})
+	hash := int(h.Sum32() & math.MaxInt32)
 
-	if now.Sub(time.Now()) < 0 {
-		return j.Next(interval)
+	if r[3] == "" {
+		bits |= 1 << (begin + hash%(end-begin+1))
+		return
 	}
 
-	j.slot = SlotSinceYear(now, interval)
+	every, _ := strconv.Atoi(r[3])
+	if every < 1 || every > end-begin+1 {
+		err = errors.New("parse err")
+		return
+	}
+	for ii := begin + hash%every; ii <= end; ii += every {
+		bits |= 1 << ii
+	}
 	return
 }
@@ -1,6 +1,7 @@
 package cron
 
 import (
+	"math/bits"
 	"testing"
 	"time"
 )
@@ -57,7 +58,7 @@ func TestJob_Next(t *testing.T) {
 		return
 	}
 
-	t.Log("slot", job.Slot())
+	t.Log("slot", job.slot)
 	t.Log(job.nextTime)
 }
 
@@ -120,3 +121,65 @@ func TestJob_SlotSinceYear(t *testing.T) {
 	slot = SlotSinceYear(now, time.Second)
 	t.Log(slot)
 }
+
+func TestJob_InitHash(t *testing.T) {
+	a := &Job{name: "a"}
+	if err := a.Init("H/15 * * * *", time.Minute, false); err != nil {
+		t.Error(err)
+		return
+	}
+	again := &Job{name: "a"}
+	if err := again.Init("H/15 * * * *", time.Minute, false); err != nil {
+		t.Error(err)
+		return
+	}
+	if a.clock.minutes != again.clock.minutes {
+		t.Error("hash not stable")
+	}
+	if n := bits.OnesCount64(a.clock.minutes); n != 4 {
+		t.Error("minutes", n)
+	}
+
+	b := &Job{name: "b"}
+	if err := b.Init("H(0-29) 2 * * *", time.Minute, false); err != nil {
+		t.Error(err)
+		return
+	}
+	if m := bits.TrailingZeros64(b.clock.minutes); bits.OnesCount64(b.clock.minutes) != 1 || m > 29 {
+		t.Error("minute", m)
+	}
+	t.Log(b.nextTime)
+
+	if err := b.Init("H(30-0) 2 * * *", time.Minute, false); err == nil {
+		t.Error("expected err")
+	}
+}
+
+func TestCron_AddJobHashUnnamed(t *testing.T) {
+	c := New()
+	minutes := make(map[uint64]struct{})
+	for i := 0; i < 10; i++ {
+		id := c.MustAddJob("H * * * *", func() {})
+		minutes[c.jobs[id-1].clock.minutes] = struct{}{}
+	}
+	// the unnamed jobs are seeded with their ids
+	if len(minutes) < 2 {
+		t.Error("not spread", len(minutes))
+	}
+}
+
+func TestJob_NextJitter(t *testing.T) {
+	job := &Job{jitter: time.Minute}
+	if err := job.Init("every 1 hour", time.Second, false); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := job.Next(time.Second); err != nil {
+		t.Error(err)
+		return
+	}
+	slot := SlotSinceEpoch(job.nextTime, time.Second)
+	if job.slot < slot || job.slot > slot+60 {
+		t.Error("slot", job.slot, slot)
+	}
+}
@@ -0,0 +1,197 @@
+package cron
+
+import (
+	"errors"
+	"time"
+)
+
+// JobState is the state of a job in the registry.
+type JobState uint8
+
+const (
+	JobStateActive JobState = iota
+	JobStatePaused
+	JobStateRemoved
+	JobStateCompleted
+)
+
+func (s JobState) String() string {
+	switch s {
+	case JobStateActive:
+		return "active"
+	case JobStatePaused:
+		return "paused"
+	case JobStateRemoved:
+		return "removed"
+	case JobStateCompleted:
+		return "completed"
+	default:
+		return "unknown"
+	}
+}
+
+// JobSnapshot is a read-only copy of a job, taken by GetJob, GetJobByName
+// and ListJobs.
+type JobSnapshot struct {
+	ID          uint32
+	Name        string
+	Description string
+	Tags        []string
+	Spec        string
+	State       JobState
+	// Next is the next scheduled run, Prev the start of the last run.
+	Next time.Time
+	Prev time.Time
+	// Running counts the runs in flight.
+	Running  int
+	Runs     uint64
+	Failures uint64
+	Skipped  uint64
+	Timeouts uint64
+}
+
+// HasTag reports whether the job has the tag.
+func (s JobSnapshot) HasTag(tag string) bool {
+	for _, v := range s.Tags {
+		if v == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// JobFilter selects the jobs returned by ListJobs.
+type JobFilter func(JobSnapshot) bool
+
+// FilterTag selects the jobs with the tag.
+func FilterTag(tag string) JobFilter {
+	return func(s JobSnapshot) bool {
+		return s.HasTag(tag)
+	}
+}
+
+// FilterState selects the jobs in the state.
+func FilterState(state JobState) JobFilter {
+	return func(s JobSnapshot) bool {
+		return s.State == state
+	}
+}
+
+// snapshot copies the job, the caller holds c.locker.
+func (j *Job) snapshot() (s JobSnapshot) {
+	s = JobSnapshot{
+		ID:          j.id,
+		Name:        j.name,
+		Description: j.description,
+		Tags:        append([]string(nil), j.tags...),
+		Spec:        j.spec,
+		Next:        j.nextTime,
+		Runs:        j.started.Load(),
+		Failures:    j.failures.Load(),
+		Timeouts:    j.timeouts.Load(),
+	}
+	switch {
+	case j.Deleted:
+		s.State = JobStateRemoved
+	case j.completed:
+		s.State = JobStateCompleted
+	case j.paused:
+		s.State = JobStatePaused
+	}
+
+	j.locker.Lock()
+	defer j.locker.Unlock()
+	s.Prev = j.prevTime
+	s.Running = j.active
+	s.Skipped = j.skipped
+	return
+}
+
+// GetJob returns a snapshot of the job, removed jobs included.
+func (c *Cron) GetJob(id uint32) (s JobSnapshot, err error) {
+	if c == nil {
+		err = errors.New("cron nil")
+		c.logger.Error(err)
+		return
+	}
+
+	c.locker.Lock()
+	defer c.unlock()
+
+	job, err := c.lookup(id)
+	if err != nil {
+		c.logger.Error(err)
+		return
+	}
+
+	s = job.snapshot()
+	return
+}
+
+// GetJobByName returns a snapshot of the job with the name.
+func (c *Cron) GetJobByName(name string) (s JobSnapshot, err error) {
+	if c == nil {
+		err = errors.New("cron nil")
+		c.logger.Error(err)
+		return
+	}
+
+	c.locker.Lock()
+	defer c.unlock()
+
+	id, ok := c.names[name]
+	if name == "" || !ok {
+		err = errors.New("job not exists")
+		c.logger.Error(err)
+		return
+	}
+
+	s = c.jobs[id-1].snapshot()
+	return
+}
+
+// ListJobs returns snapshots of the jobs not removed that match every filter.
+func (c *Cron) ListJobs(filters ...JobFilter) (list []JobSnapshot) {
+	if c == nil {
+		return
+	}
+
+	c.locker.Lock()
+	defer c.unlock()
+
+LOOP:
+	for _, job := range c.jobs {
+		if job.Deleted {
+			continue
+		}
+		s := job.snapshot()
+		for _, filter := range filters {
+			if !filter(s) {
+				continue LOOP
+			}
+		}
+		list = append(list, s)
+	}
+	return
+}
+
+// RemoveByTag removes every job with the tag and returns their ids.
+func (c *Cron) RemoveByTag(tag string) (ids []uint32, err error) {
+	if c == nil {
+		err = errors.New("cron nil")
+		c.logger.Error(err)
+		return
+	}
+
+	c.locker.Lock()
+	defer c.unlock()
+
+	for _, job := range c.jobs {
+		if job.Deleted || !job.snapshot().HasTag(tag) {
+			continue
+		}
+		c.removeJob(job)
+		ids = append(ids, job.id)
+	}
+	return
+}
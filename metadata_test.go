@@ -0,0 +1,73 @@
+package cron
+
+import (
+	"testing"
+)
+
+func TestCron_GetJob(t *testing.T) {
+	c := New()
+	id := c.MustAddJob("every 1 minute", func() {}, JobName("export"), JobDescription("nightly export"), JobTags("etl", "db"))
+	if _, err := c.AddJob("every 1 minute", func() {}, JobName("export")); err == nil {
+		t.Error("expected err, name exists")
+	}
+
+	s, err := c.GetJob(id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if s.Name != "export" || s.Description != "nightly export" || !s.HasTag("db") || s.Spec != "every 1 minute" || s.State != JobStateActive {
+		t.Errorf("%+v", s)
+	}
+	s.Tags[0] = "changed"
+	if c.jobs[id-1].tags[0] != "etl" {
+		t.Error("snapshot shares tags")
+	}
+
+	if s, err = c.GetJobByName("export"); err != nil || s.ID != id {
+		t.Error(s.ID, err)
+	}
+	if _, err = c.GetJobByName("import"); err == nil {
+		t.Error("expected err")
+	}
+
+	c.MustPauseJob(id)
+	if s, _ = c.GetJob(id); s.State != JobStatePaused {
+		t.Error("state", s.State)
+	}
+	c.MustRemoveJob(id)
+	if s, _ = c.GetJob(id); s.State != JobStateRemoved {
+		t.Error("state", s.State)
+	}
+	// the name is free again
+	if _, err = c.AddJob("every 1 minute", func() {}, JobName("export")); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCron_ListJobs(t *testing.T) {
+	c := New()
+	a := c.MustAddJob("every 1 minute", func() {}, JobTags("etl"))
+	b := c.MustAddJob("every 1 minute", func() {}, JobTags("etl", "db"))
+	c.MustAddJob("every 1 minute", func() {}, JobTags("db"))
+	c.MustPauseJob(b)
+
+	if list := c.ListJobs(); len(list) != 3 {
+		t.Error("all", len(list))
+	}
+	if list := c.ListJobs(FilterTag("etl"), FilterState(JobStateActive)); len(list) != 1 || list[0].ID != a {
+		t.Error("filter", list)
+	}
+
+	ids, err := c.RemoveByTag("etl")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(ids) != 2 || ids[0] != a || ids[1] != b {
+		t.Error("removed", ids)
+	}
+	if list := c.ListJobs(); len(list) != 1 || !list[0].HasTag("db") {
+		t.Error("left", list)
+	}
+}
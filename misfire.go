@@ -0,0 +1,87 @@
+package cron
+
+import (
+	"time"
+)
+
+// MisfirePolicy decides what happens to runs missed while the process was
+// paused or the scheduler fell behind.
+type MisfirePolicy uint8
+
+const (
+	// MisfireSkip drops the missed runs and waits for the next one, the default.
+	MisfireSkip MisfirePolicy = iota
+	// MisfireFireOnce runs once now for all the missed runs.
+	MisfireFireOnce
+	// MisfireFireAll runs every missed run now, at most the misfire limit.
+	MisfireFireAll
+	// MisfireGrace runs once now if the last missed run is within the grace window.
+	MisfireGrace
+)
+
+func (p MisfirePolicy) String() string {
+	switch p {
+	case MisfireSkip:
+		return "skip"
+	case MisfireFireOnce:
+		return "fire once"
+	case MisfireFireAll:
+		return "fire all"
+	case MisfireGrace:
+		return "grace"
+	default:
+		return "unknown"
+	}
+}
+
+// misfire applies the misfire policy to the runs Job.Next skipped.
+func (c *Cron) misfire(job *Job) {
+	if job.missed == 0 {
+		return
+	}
+	missed := job.missed
+	missedAt := job.missedAt
+	scheduled := job.missedTimes
+	job.forget()
+
+	var runs []time.Time
+	switch job.misfirePolicy {
+	case MisfireFireOnce:
+		runs = []time.Time{missedAt}
+	case MisfireFireAll:
+		runs = scheduled
+	case MisfireGrace:
+		if c.nowFunc().Sub(missedAt) <= job.misfireGrace {
+			runs = []time.Time{missedAt}
+		}
+	}
+
+	c.logger.Errorf("job %d misfire: missed %d, last %s, policy %s, run %d", job.id, missed, missedAt, job.misfirePolicy, len(runs))
+	c.post(Event{
+		Type:      EventJobMisfired,
+		JobID:     job.id,
+		Name:      job.name,
+		Scheduled: missedAt,
+		Next:      job.nextTime,
+		Missed:    missed,
+	})
+	for _, t := range runs {
+		c.dispatch(job, t)
+	}
+}
+
+// miss counts a run the job missed, MisfireFireAll keeps its time, at most
+// the misfire limit.
+func (j *Job) miss(t time.Time) {
+	j.missed++
+	j.missedAt = t
+	if j.misfirePolicy == MisfireFireAll && (j.misfireLimit <= 0 || len(j.missedTimes) < j.misfireLimit) {
+		j.missedTimes = append(j.missedTimes, t)
+	}
+}
+
+// forget drops the missed runs.
+func (j *Job) forget() {
+	j.missed = 0
+	j.missedTimes = nil
+}
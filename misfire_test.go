@@ -0,0 +1,93 @@
+package cron
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCron_Misfire(t *testing.T) {
+	for _, v := range []struct {
+		options []JobOptions
+		runs    int32
+	}{
+		{nil, 0},
+		{[]JobOptions{JobMisfire(MisfireFireOnce)}, 1},
+		{[]JobOptions{JobMisfire(MisfireFireAll), JobMisfireLimit(3)}, 3},
+		{[]JobOptions{JobMisfire(MisfireGrace), JobMisfireGrace(time.Minute)}, 1},
+		{[]JobOptions{JobMisfire(MisfireGrace), JobMisfireGrace(time.Millisecond)}, 0},
+	} {
+		var runs atomic.Int32
+		c := New(WithSecond())
+		id, err := c.AddJob("every 1 second", func() {
+			runs.Add(1)
+		}, v.options...)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		job := c.jobs[id-1]
+		job.nextTime = time.Now().Add(-10 * time.Second).Truncate(time.Second)
+		if err = job.Next(c.interval); err != nil {
+			t.Error(err)
+			return
+		}
+		if job.missed < 9 {
+			t.Error("missed", job.missed)
+		}
+		if v.runs == 0 {
+			job.missedAt = job.missedAt.Add(-time.Second)
+		}
+		c.misfire(job)
+		time.Sleep(time.Millisecond * 10)
+		if runs.Load() != v.runs {
+			t.Error(job.misfirePolicy, "runs", runs.Load(), v.runs)
+		}
+		if job.missed != 0 {
+			t.Error("missed not reset")
+		}
+	}
+}
+
+func TestCron_MisfireFireAllScheduled(t *testing.T) {
+	c := New(WithSecond())
+	scheduled := make(chan time.Time, 10)
+	id := c.MustAddContextJob("every 1 second", func(ctx context.Context) error {
+		info, _ := JobInfoFromContext(ctx)
+		scheduled <- info.Scheduled
+		return nil
+	}, JobMisfire(MisfireFireAll), JobMisfireLimit(3))
+	job := c.jobs[id-1]
+	start := time.Now().Add(-10 * time.Second).Truncate(time.Second)
+	job.nextTime = start
+	if err := job.Next(c.interval); err != nil {
+		t.Error(err)
+		return
+	}
+	c.misfire(job)
+	c.wg.Wait()
+	close(scheduled)
+
+	// the first runs missed, each at its own time
+	var times []time.Time
+	for v := range scheduled {
+		times = append(times, v)
+	}
+	sort.Slice(times, func(i, j int) bool {
+		return times[i].Before(times[j])
+	})
+	if len(times) != 3 {
+		t.Error("runs", times)
+		return
+	}
+	for i, v := range times {
+		if !v.Equal(start.Add(time.Duration(i+1) * time.Second)) {
+			t.Error("scheduled", i, v, start)
+		}
+	}
+	if len(job.missedTimes) != 0 {
+		t.Error("missed times not reset")
+	}
+}
@@ -35,3 +35,306 @@ func WithDivisibility() Options {
 		t.divisibility = true
 	}
 }
+
+// WithJumpThreshold sets how far the wall clock may drift from the wheel
+// before the wheel resyncs, default one interval.
+func WithJumpThreshold(threshold time.Duration) Options {
+	return func(t *Cron) {
+		t.jumpThreshold = threshold
+	}
+}
+
+// WithNowFunc replaces time.Now for the wheel and its jobs, mostly for tests.
+// The wheel still ticks every interval of real time, a clock drifting from
+// it by more than WithJumpThreshold resyncs the wheel.
+func WithNowFunc(nowFunc func() time.Time) Options {
+	return func(t *Cron) {
+		t.nowFunc = nowFunc
+	}
+}
+
+// WithWorkerPool runs the jobs on a fixed number of workers instead of a
+// goroutine per run, queueSize runs wait for a free worker.
+func WithWorkerPool(workers int, queueSize int) Options {
+	return func(t *Cron) {
+		t.workers = workers
+		t.queueSize = queueSize
+	}
+}
+
+// WithBackPressure sets what a full worker pool queue does, default BackPressureBlock.
+func WithBackPressure(backPressure BackPressure) Options {
+	return func(t *Cron) {
+		t.backPressure = backPressure
+	}
+}
+
+// WithTimeout sets the default timeout of the jobs, see JobTimeout.
+func WithTimeout(timeout time.Duration) Options {
+	return func(t *Cron) {
+		t.timeout = timeout
+	}
+}
+
+// WithTimeoutGrace sets the default timeout grace of the jobs, see JobTimeoutGrace.
+func WithTimeoutGrace(grace time.Duration) Options {
+	return func(t *Cron) {
+		t.timeoutGrace = grace
+	}
+}
+
+// WithChain wraps the callback of every job, outside the JobChain of the job.
+func WithChain(wrappers ...JobWrapper) Options {
+	return func(t *Cron) {
+		t.chain = append(t.chain, wrappers...)
+	}
+}
+
+// WithEventBuffer sets the buffer of the Events channel, default 100.
+func WithEventBuffer(size int) Options {
+	return func(t *Cron) {
+		t.eventBuffer = size
+	}
+}
+
+// WithListener registers a listener for the events, see AddListener.
+func WithListener(listener Listener) Options {
+	return func(t *Cron) {
+		t.listeners = append(t.listeners, listener)
+	}
+}
+
+// WithPriorityAging sets how long a run waits in the worker pool queue to
+// gain one priority, so low priority jobs are not starved, default one
+// interval, a negative aging keeps the priorities strict.
+func WithPriorityAging(aging time.Duration) Options {
+	return func(t *Cron) {
+		t.priorityAging = aging
+	}
+}
+
+// WithGroup defines a concurrency group, at most limit runs of its jobs are
+// in flight, the policy decides whether a run waits or is skipped when the
+// group is full. See JobGroup.
+func WithGroup(name string, limit int, policy GroupPolicy) Options {
+	return func(t *Cron) {
+		if t.groups == nil {
+			t.groups = make(map[string]*group)
+		}
+		t.groups[name] = newGroup(name, limit, policy)
+	}
+}
+
+// WithRateLimit limits the scheduled runs of every job, RunNow and retries
+// are not limited.
+func WithRateLimit(limit RateLimit) Options {
+	return func(t *Cron) {
+		t.rateLimit = newBucket(limit)
+	}
+}
+
+// WithTagRateLimit limits the scheduled runs of the jobs with the tag, on
+// top of WithRateLimit. See JobTags.
+func WithTagRateLimit(tag string, limit RateLimit) Options {
+	return func(t *Cron) {
+		if t.tagRateLimits == nil {
+			t.tagRateLimits = make(map[string]*bucket)
+		}
+		t.tagRateLimits[tag] = newBucket(limit)
+	}
+}
+
+// WithHistorySize sets how many runs every job keeps for History, default
+// 10, a negative size keeps none.
+func WithHistorySize(size int) Options {
+	return func(t *Cron) {
+		t.historySize = size
+	}
+}
+
+type JobOptions func(j *Job)
+
+// JobName names the job, unique in the cron, the name also seeds the "H"
+// fields of its spec.
+func JobName(name string) JobOptions {
+	return func(j *Job) {
+		j.name = name
+	}
+}
+
+// JobDescription describes the job.
+func JobDescription(description string) JobOptions {
+	return func(j *Job) {
+		j.description = description
+	}
+}
+
+// JobTags labels the job, see ListJobs and RemoveByTag.
+func JobTags(tags ...string) JobOptions {
+	return func(j *Job) {
+		j.tags = append(j.tags, tags...)
+	}
+}
+
+// JobJitter delays every run by a random number of whole intervals up to
+// jitter, at least one interval. A run is never delayed past the next one.
+func JobJitter(jitter time.Duration) JobOptions {
+	return func(j *Job) {
+		j.jitter = jitter
+	}
+}
+
+// JobMisfire sets how runs missed while the scheduler was late are handled.
+func JobMisfire(policy MisfirePolicy) JobOptions {
+	return func(j *Job) {
+		j.misfirePolicy = policy
+	}
+}
+
+// JobMisfireLimit caps the runs MisfireFireAll makes up, 0 means no cap.
+func JobMisfireLimit(limit int) JobOptions {
+	return func(j *Job) {
+		j.misfireLimit = limit
+	}
+}
+
+// JobMisfireGrace sets the window of MisfireGrace.
+func JobMisfireGrace(grace time.Duration) JobOptions {
+	return func(j *Job) {
+		j.misfireGrace = grace
+	}
+}
+
+// JobTimeout cancels the context of a run taking longer than timeout.
+func JobTimeout(timeout time.Duration) JobOptions {
+	return func(j *Job) {
+		j.timeout = timeout
+	}
+}
+
+// JobTimeoutGrace sets how long a timed out run may keep running before the
+// overlap policy treats the job as free again, default 0.
+func JobTimeoutGrace(grace time.Duration) JobOptions {
+	return func(j *Job) {
+		j.timeoutGrace = grace
+	}
+}
+
+// JobOverlap sets what happens when the job is due while still running.
+func JobOverlap(policy OverlapPolicy) JobOptions {
+	return func(j *Job) {
+		j.overlapPolicy = policy
+	}
+}
+
+// JobQueueLimit sets how many runs OverlapQueue keeps waiting, default 1.
+func JobQueueLimit(limit int) JobOptions {
+	return func(j *Job) {
+		j.queueLimit = limit
+	}
+}
+
+// JobRetry retries failed runs, see RetryPolicy.
+func JobRetry(policy RetryPolicy) JobOptions {
+	return func(j *Job) {
+		j.retry = &policy
+	}
+}
+
+// JobChain wraps the callback of the job.
+func JobChain(wrappers ...JobWrapper) JobOptions {
+	return func(j *Job) {
+		j.chain = append(j.chain, wrappers...)
+	}
+}
+
+// JobDivisibility aligns the job to its every value, see WithDivisibility.
+func JobDivisibility(divisibility bool) JobOptions {
+	return func(j *Job) {
+		j.divisibility = divisibility
+	}
+}
+
+// JobLocation evaluates the spec in the location, default time.Local.
+func JobLocation(location *time.Location) JobOptions {
+	return func(j *Job) {
+		j.location = location
+	}
+}
+
+// JobStartAt skips the runs before start.
+func JobStartAt(start time.Time) JobOptions {
+	return func(j *Job) {
+		j.startAt = start
+	}
+}
+
+// JobEndAt stops the job after end.
+func JobEndAt(end time.Time) JobOptions {
+	return func(j *Job) {
+		j.endAt = end
+	}
+}
+
+// JobMaxRuns stops the job after max scheduled runs, RunNow and retries
+// are not counted.
+func JobMaxRuns(max uint64) JobOptions {
+	return func(j *Job) {
+		j.maxRuns = max
+	}
+}
+
+// JobImmediate runs the job once when it is added, or when the cron starts.
+func JobImmediate() JobOptions {
+	return func(j *Job) {
+		j.immediate = true
+	}
+}
+
+// JobAutoRemove removes the job from the cron once it is completed, see
+// JobEndAt and JobMaxRuns.
+func JobAutoRemove() JobOptions {
+	return func(j *Job) {
+		j.autoRemove = true
+	}
+}
+
+// JobOnComplete is called in its own goroutine once the job is completed.
+func JobOnComplete(hook func(JobSnapshot)) JobOptions {
+	return func(j *Job) {
+		j.onComplete = hook
+	}
+}
+
+// JobDependsOn runs the job after all the upstream jobs finished with the
+// trigger, see DependOn. Use the spec "@manual" for a job that only runs
+// after others.
+func JobDependsOn(on Trigger, upstream ...uint32) JobOptions {
+	return func(j *Job) {
+		j.trigger = on
+		j.upstream = append(j.upstream, upstream...)
+	}
+}
+
+// JobHistorySize sets how many runs the job keeps for History, see
+// WithHistorySize.
+func JobHistorySize(size int) JobOptions {
+	return func(j *Job) {
+		j.historySize = size
+	}
+}
+
+// JobPriority orders the jobs due in the same slot and waiting in the worker
+// pool queue, higher first, default 0.
+func JobPriority(priority int) JobOptions {
+	return func(j *Job) {
+		j.priority = priority
+	}
+}
+
+// JobGroup puts the job in the concurrency group defined by WithGroup.
+func JobGroup(name string) JobOptions {
+	return func(j *Job) {
+		j.groupName = name
+	}
+}
@@ -1,7 +1,9 @@
 package cron
 
 import (
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestWithSecond(t *testing.T) {
@@ -29,3 +31,307 @@ func TestWithDivisibility(t *testing.T) {
 	c := New(WithDivisibility())
 	t.Log("divisibility", c.divisibility)
 }
+
+func TestJobName(t *testing.T) {
+	c := New()
+	_, err := c.AddJob("H * * * *", func() {}, JobName("report"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	t.Log("name", c.jobs[0].name)
+}
+
+func TestJobJitter(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.Local)
+	clock := &fakeNow{now: now}
+	c := New(WithNowFunc(clock.Now))
+	if _, err := c.AddJob("every 1 minute", func() {}, JobJitter(time.Second*30)); err == nil {
+		t.Error("expected err, shorter than the interval")
+	}
+
+	for _, v := range []struct {
+		spec   string
+		jitter time.Duration
+		max    uint64
+	}{
+		{"every 1 hour", time.Minute * 30, 30},
+		{"0 * * * *", time.Minute * 30, 30},
+		// never past the next run
+		{"every 5 minutes", time.Hour, 4},
+	} {
+		id, err := c.AddJob(v.spec, func() {}, JobJitter(v.jitter))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		job := c.jobs[id-1]
+		offsets := make(map[uint64]struct{})
+		for i := 0; i < 50; i++ {
+			if err = job.Next(c.interval); err != nil {
+				t.Error(err)
+				return
+			}
+			offset := job.slot - SlotSinceEpoch(job.nextTime, c.interval)
+			if offset > v.max {
+				t.Error(v.spec, "offset", offset)
+			}
+			offsets[offset] = struct{}{}
+		}
+		if len(offsets) < 2 {
+			t.Error(v.spec, "not spread", offsets)
+		}
+	}
+}
+
+func TestJobJitterNoMisfire(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.Local)
+	clock := &fakeNow{now: now}
+	c := New(WithNowFunc(clock.Now))
+	c.turning = true
+	var misfires, runs atomic.Int32
+	c.AddListener(func(event Event) {
+		if event.Type == EventJobMisfired {
+			misfires.Add(1)
+		}
+	})
+	c.MustAddJob("every 5 minutes", func() {
+		runs.Add(1)
+	}, JobJitter(time.Hour))
+
+	for i := 0; i < 60; i++ {
+		now = clock.Add(time.Minute)
+		c.locker.Lock()
+		c.tick(SlotSinceEpoch(now, c.interval))
+		c.unlock()
+	}
+	c.wg.Wait()
+	if misfires.Load() != 0 || runs.Load() < 6 {
+		t.Error("misfires", misfires.Load(), "runs", runs.Load())
+	}
+}
+
+func TestJobDivisibility(t *testing.T) {
+	c := New(WithDivisibility())
+	_, err := c.AddJob("every 5 minute", func() {}, JobDivisibility(false))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	t.Log("divisibility", c.jobs[0].divisibility)
+}
+
+func TestJobLocation(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*3600)
+	c := New()
+	id, err := c.AddJob("0 3 * * *", func() {}, JobLocation(tokyo))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	job := c.jobs[id-1]
+	if err = job.Next(c.interval); err != nil {
+		t.Error(err)
+		return
+	}
+	if next := job.nextTime.In(tokyo); next.Hour() != 3 || next.Minute() != 0 {
+		t.Error("next time", next)
+	}
+}
+
+func TestJobStartAt(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.Local)
+	clock := &fakeNow{now: now}
+	c := New(WithNowFunc(clock.Now))
+	for _, spec := range []string{"every 1 hour", "0 * * * *"} {
+		id, err := c.AddJob(spec, func() {}, JobStartAt(now.AddDate(0, 0, 3).Add(time.Minute)))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		job := c.jobs[id-1]
+		if err = job.Next(c.interval); err != nil {
+			t.Error(err)
+			return
+		}
+		if !job.nextTime.Equal(now.AddDate(0, 0, 3).Add(time.Hour)) || job.missed != 0 {
+			t.Error(spec, "next time", job.nextTime, job.missed)
+		}
+	}
+}
+
+func TestJobStartAtEvery(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.Local)
+	clock := &fakeNow{now: now}
+	c := New(WithSecond(), WithNowFunc(clock.Now))
+	start := now.AddDate(1, 1, 10).Add(time.Minute + time.Second)
+	for _, spec := range []string{"every 7 seconds", "every 5 minutes", "every 3 hours", "every 2 days", "every 1 week", "every 5 months"} {
+		id, err := c.AddJob(spec, func() {}, JobStartAt(start))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		job := c.jobs[id-1]
+
+		// the jump lands where walking one run at a time does
+		expected := job.nextTime
+		for expected.Before(start) {
+			if expected, err = job.after(expected, c.interval); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		if err = job.Next(c.interval); err != nil {
+			t.Error(err)
+			return
+		}
+		if !job.nextTime.Equal(expected) || job.missed != 0 {
+			t.Error(spec, "next time", job.nextTime, expected, job.missed)
+		}
+	}
+}
+
+func TestJobEndAt(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.Local)
+	clock := &fakeNow{now: now}
+	c := New(WithNowFunc(clock.Now))
+	id, err := c.AddJob("0 3 * * *", func() {}, JobEndAt(now.Add(time.Hour)))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	job := c.jobs[id-1]
+	if err = job.Next(c.interval); err != nil {
+		t.Error(err)
+		return
+	}
+	if !job.completed || job.slot != 0 {
+		t.Error("not completed", job.nextTime)
+	}
+}
+
+func TestJobMaxRuns(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.Local)
+	clock := &fakeNow{now: now}
+	c := New(WithNowFunc(clock.Now))
+	var runs atomic.Int32
+	id, err := c.AddJob("every 1 minute", func() {
+		runs.Add(1)
+	}, JobMaxRuns(2))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	job := c.jobs[id-1]
+	if err = job.Next(c.interval); err != nil {
+		t.Error(err)
+		return
+	}
+	for i := 0; i < 5; i++ {
+		now = clock.Add(time.Minute)
+		c.tick(SlotSinceEpoch(now, c.interval))
+	}
+	time.Sleep(time.Millisecond * 10)
+	if runs.Load() != 2 || !job.completed {
+		t.Error("runs", runs.Load(), job.completed)
+	}
+}
+
+func TestJobImmediate(t *testing.T) {
+	c := New(WithSecond())
+	c.MustStart()
+	defer c.MustStop()
+	// wait for the wheel
+	for {
+		c.locker.Lock()
+		turning := c.turning
+		c.locker.Unlock()
+		if turning {
+			break
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	ran := make(chan struct{})
+	if _, err := c.AddJob("0 3 * * *", func() {
+		close(ran)
+	}, JobImmediate()); err != nil {
+		t.Error(err)
+		return
+	}
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Error("not run")
+	}
+}
+
+func TestJobOnComplete(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.Local)
+	clock := &fakeNow{now: now}
+	c := New(WithNowFunc(clock.Now))
+	completed := make(chan JobSnapshot, 1)
+	var events atomic.Int32
+	c.AddListener(func(event Event) {
+		if event.Type == EventJobCompleted {
+			events.Add(1)
+		}
+	})
+	id, err := c.AddJob("every 1 minute", func() {}, JobMaxRuns(1), JobOnComplete(func(s JobSnapshot) {
+		completed <- s
+	}))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err = c.jobs[id-1].Next(c.interval); err != nil {
+		t.Error(err)
+		return
+	}
+	for i := 0; i < 3; i++ {
+		now = clock.Add(time.Minute)
+		c.locker.Lock()
+		c.tick(SlotSinceEpoch(now, c.interval))
+		c.unlock()
+	}
+
+	select {
+	case s := <-completed:
+		if s.ID != id || s.State != JobStateCompleted {
+			t.Errorf("%+v", s)
+		}
+	case <-time.After(time.Second):
+		t.Error("hook not called")
+	}
+	if events.Load() != 1 {
+		t.Error("events", events.Load())
+	}
+	if list := c.ListJobs(FilterState(JobStateCompleted)); len(list) != 1 {
+		t.Error("completed", list)
+	}
+}
+
+func TestJobAutoRemove(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.Local)
+	clock := &fakeNow{now: now}
+	c := New(WithNowFunc(clock.Now))
+	id, err := c.AddJob("every 1 minute", func() {}, JobName("once"), JobEndAt(now.Add(time.Minute)), JobAutoRemove())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err = c.jobs[id-1].Next(c.interval); err != nil {
+		t.Error(err)
+		return
+	}
+	for i := 0; i < 3; i++ {
+		now = clock.Add(time.Minute)
+		c.tick(SlotSinceEpoch(now, c.interval))
+	}
+	if s, _ := c.GetJob(id); s.State != JobStateRemoved {
+		t.Error("state", s.State)
+	}
+	if _, err = c.GetJobByName("once"); err == nil {
+		t.Error("expected err")
+	}
+}
@@ -0,0 +1,120 @@
+package cron
+
+// OverlapPolicy decides what happens when a job is due while its previous
+// run is still running.
+type OverlapPolicy uint8
+
+const (
+	// OverlapAllow runs concurrently, the default.
+	OverlapAllow OverlapPolicy = iota
+	// OverlapSkip skips the run.
+	OverlapSkip
+	// OverlapQueue runs it after the running one, at most the queue limit wait.
+	OverlapQueue
+	// OverlapReplace cancels the running one and runs.
+	OverlapReplace
+)
+
+func (p OverlapPolicy) String() string {
+	switch p {
+	case OverlapAllow:
+		return "allow"
+	case OverlapSkip:
+		return "skip"
+	case OverlapQueue:
+		return "queue"
+	case OverlapReplace:
+		return "replace"
+	default:
+		return "unknown"
+	}
+}
+
+// admit applies the overlap policy, start is false when the run is skipped
+// or queued behind the running one.
+func (j *Job) admit(info JobInfo) (start bool, skipped bool) {
+	j.locker.Lock()
+	defer j.locker.Unlock()
+
+	if j.active == 0 || j.overlapPolicy == OverlapAllow {
+		j.active++
+		start = true
+		return
+	}
+
+	switch j.overlapPolicy {
+	case OverlapQueue:
+		limit := j.queueLimit
+		if limit < 1 {
+			limit = 1
+		}
+		if len(j.pending) < limit {
+			j.pending = append(j.pending, info)
+			return
+		}
+	case OverlapReplace:
+		for r := range j.runs {
+			r.cancel()
+		}
+		j.active++
+		start = true
+		return
+	}
+
+	j.skipped++
+	skipped = true
+	return
+}
+
+// release ends a run, it hands back the next queued run if any. Queued runs
+// are dropped when the cron has stopped.
+func (j *Job) release(r *run, stopped bool) (info JobInfo, ok bool) {
+	j.locker.Lock()
+	defer j.locker.Unlock()
+
+	if r.detached {
+		// its slot went to the next run at the timeout
+		return
+	}
+
+	if stopped {
+		for _, v := range j.pending {
+			v.handle.finish(ErrRunSkipped)
+		}
+		j.skipped += uint64(len(j.pending))
+		j.pending = nil
+	}
+	if len(j.pending) == 0 {
+		j.active--
+		return
+	}
+
+	info = j.pending[0]
+	j.pending = j.pending[1:]
+	ok = true
+	return
+}
+
+// Skipped returns how many runs the overlap policy or SkipIfStillRunning
+// skipped.
+func (j *Job) Skipped() uint64 {
+	j.locker.Lock()
+	defer j.locker.Unlock()
+
+	return j.skipped
+}
+
+// SkippedRuns returns how many runs of the job the overlap policy or
+// SkipIfStillRunning skipped.
+func (c *Cron) SkippedRuns(id uint32) (skipped uint64, err error) {
+	c.locker.Lock()
+	job, err := c.lookup(id)
+	c.unlock()
+	if err != nil {
+		c.logger.Error(err)
+		return
+	}
+
+	skipped = job.Skipped()
+	return
+}
@@ -0,0 +1,50 @@
+package cron
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testOverlap(t *testing.T, policy OverlapPolicy, options ...JobOptions) (runs int32, cancelled int32, skipped uint64) {
+	var r, cl atomic.Int32
+	c := New()
+	id, err := c.AddContextJob("every 1 minute", func(ctx context.Context) error {
+		r.Add(1)
+		select {
+		case <-ctx.Done():
+			cl.Add(1)
+		case <-time.After(time.Millisecond * 50):
+		}
+		return nil
+	}, append(options, JobOverlap(policy))...)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for i := 0; i < 3; i++ {
+		c.dispatch(c.jobs[id-1], time.Now())
+	}
+	c.wg.Wait()
+	skipped, _ = c.SkippedRuns(id)
+	return r.Load(), cl.Load(), skipped
+}
+
+func TestJobOverlap(t *testing.T) {
+	if runs, _, skipped := testOverlap(t, OverlapAllow); runs != 3 || skipped != 0 {
+		t.Error("allow", runs, skipped)
+	}
+	if runs, _, skipped := testOverlap(t, OverlapSkip); runs != 1 || skipped != 2 {
+		t.Error("skip", runs, skipped)
+	}
+	if runs, _, skipped := testOverlap(t, OverlapQueue); runs != 2 || skipped != 1 {
+		t.Error("queue", runs, skipped)
+	}
+	if runs, _, skipped := testOverlap(t, OverlapQueue, JobQueueLimit(2)); runs != 3 || skipped != 0 {
+		t.Error("queue 2", runs, skipped)
+	}
+	if runs, cancelled, skipped := testOverlap(t, OverlapReplace); runs != 3 || cancelled != 2 || skipped != 0 {
+		t.Error("replace", runs, cancelled, skipped)
+	}
+}
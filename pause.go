@@ -0,0 +1,151 @@
+package cron
+
+import (
+	"errors"
+)
+
+func (c *Cron) MustPauseJob(id uint32) {
+	_ = c.PauseJob(id)
+}
+
+// PauseJob stops firing the job until ResumeJob, it keeps its id, schedule
+// and state. A run in flight is not cancelled.
+func (c *Cron) PauseJob(id uint32) (err error) {
+	if c == nil {
+		err = errors.New("cron nil")
+		c.logger.Error(err)
+		return
+	}
+
+	c.locker.Lock()
+	defer c.unlock()
+
+	job, err := c.lookup(id)
+	if err == nil && job.Deleted {
+		err = errors.New("job removed")
+	}
+	if err == nil && job.paused {
+		err = errors.New("job already paused")
+	}
+	if err != nil {
+		c.logger.Error(err)
+		return
+	}
+
+	job.paused = true
+	c.logger.Info("job paused:", id)
+	c.post(Event{Type: EventJobPaused, JobID: id, Name: job.name})
+	return
+}
+
+func (c *Cron) MustResumeJob(id uint32) {
+	_ = c.ResumeJob(id)
+}
+
+// ResumeJob fires the paused job again, the runs missed while paused go to
+// its misfire policy.
+func (c *Cron) ResumeJob(id uint32) (err error) {
+	if c == nil {
+		err = errors.New("cron nil")
+		c.logger.Error(err)
+		return
+	}
+
+	c.locker.Lock()
+	defer c.unlock()
+
+	job, err := c.lookup(id)
+	if err == nil && job.Deleted {
+		err = errors.New("job removed")
+	}
+	if err == nil && !job.paused {
+		err = errors.New("job not paused")
+	}
+	if err != nil {
+		c.logger.Error(err)
+		return
+	}
+
+	job.paused = false
+	c.logger.Info("job resumed:", id)
+	c.post(Event{Type: EventJobResumed, JobID: id, Name: job.name})
+	if c.behind(job) {
+		c.catchUp(job)
+	}
+	return
+}
+
+func (c *Cron) MustPause() {
+	_ = c.Pause()
+}
+
+// Pause stops firing every job until Resume, the wheel keeps turning so it
+// does not lose its position.
+func (c *Cron) Pause() (err error) {
+	if c == nil {
+		err = errors.New("cron nil")
+		c.logger.Error(err)
+		return
+	}
+
+	c.locker.Lock()
+	defer c.unlock()
+
+	if c.paused {
+		err = errors.New("cron already paused")
+		c.logger.Error(err)
+		return
+	}
+
+	c.paused = true
+	c.logger.Info("cron paused")
+	c.post(Event{Type: EventCronPaused})
+	return
+}
+
+func (c *Cron) MustResume() {
+	_ = c.Resume()
+}
+
+// Resume fires the jobs again, the runs missed while paused go to the misfire
+// policy of each job.
+func (c *Cron) Resume() (err error) {
+	if c == nil {
+		err = errors.New("cron nil")
+		c.logger.Error(err)
+		return
+	}
+
+	c.locker.Lock()
+	defer c.unlock()
+
+	if !c.paused {
+		err = errors.New("cron not paused")
+		c.logger.Error(err)
+		return
+	}
+
+	c.paused = false
+	c.logger.Info("cron resumed")
+	c.post(Event{Type: EventCronResumed})
+	for _, job := range c.jobs {
+		if !job.Deleted && !job.paused && c.behind(job) {
+			c.catchUp(job)
+		}
+	}
+	return
+}
+
+// Paused reports whether the cron is paused.
+func (c *Cron) Paused() bool {
+	c.locker.Lock()
+	defer c.unlock()
+
+	return c.paused
+}
+
+// behind reports whether the slot of the job has been ticked already, the
+// caller holds c.locker.
+func (c *Cron) behind(job *Job) bool {
+	return c.turning && !job.completed && !job.manual && job.slot <= c.slot
+}
@@ -0,0 +1,126 @@
+package cron
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCron_PauseJob(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.Local)
+	clock := &fakeNow{now: now}
+	c := New(WithNowFunc(clock.Now))
+	c.turning = true
+	var runs atomic.Int32
+	id := c.MustAddJob("every 1 minute", func() {
+		runs.Add(1)
+	}, JobMisfire(MisfireFireOnce))
+	job := c.jobs[id-1]
+	if err := job.Next(c.interval); err != nil {
+		t.Error(err)
+		return
+	}
+
+	c.tick(SlotSinceEpoch(now, c.interval))
+	if err := c.PauseJob(id); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := c.PauseJob(id); err == nil {
+		t.Error("expected err")
+	}
+	for i := 0; i < 3; i++ {
+		now = clock.Add(time.Minute)
+		c.tick(SlotSinceEpoch(now, c.interval))
+	}
+	time.Sleep(time.Millisecond * 10)
+	if runs.Load() != 0 {
+		t.Error("runs while paused", runs.Load())
+	}
+
+	if err := c.ResumeJob(id); err != nil {
+		t.Error(err)
+		return
+	}
+	time.Sleep(time.Millisecond * 10)
+	if runs.Load() != 1 {
+		t.Error("runs after resume", runs.Load())
+	}
+	if job.slot != c.slot+1 {
+		t.Error("slot", job.slot, c.slot)
+	}
+	if err := c.ResumeJob(id); err == nil {
+		t.Error("expected err")
+	}
+}
+
+func TestCron_ResumeRemovedJob(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.Local)
+	clock := &fakeNow{now: now}
+	c := New(WithNowFunc(clock.Now))
+	c.turning = true
+	var runs atomic.Int32
+	id := c.MustAddJob("every 1 minute", func() {
+		runs.Add(1)
+	}, JobMisfire(MisfireFireOnce))
+	if err := c.jobs[id-1].Next(c.interval); err != nil {
+		t.Error(err)
+		return
+	}
+
+	c.tick(SlotSinceEpoch(now, c.interval))
+	c.MustPauseJob(id)
+	c.MustRemoveJob(id)
+	for i := 0; i < 3; i++ {
+		now = clock.Add(time.Minute)
+		c.tick(SlotSinceEpoch(now, c.interval))
+	}
+	if err := c.ResumeJob(id); err == nil {
+		t.Error("expected err")
+	}
+	c.wg.Wait()
+	if runs.Load() != 0 {
+		t.Error("runs after remove", runs.Load())
+	}
+}
+
+func TestCron_Pause(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.Local)
+	clock := &fakeNow{now: now}
+	c := New(WithNowFunc(clock.Now))
+	c.turning = true
+	var runs atomic.Int32
+	c.MustAddJob("every 1 minute", func() {
+		runs.Add(1)
+	})
+	for _, job := range c.jobs {
+		if err := job.Next(c.interval); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	c.tick(SlotSinceEpoch(now, c.interval))
+	c.MustPause()
+	if !c.Paused() {
+		t.Error("not paused")
+	}
+	for i := 0; i < 3; i++ {
+		now = clock.Add(time.Minute)
+		c.tick(SlotSinceEpoch(now, c.interval))
+	}
+	if c.slot != SlotSinceEpoch(now, c.interval) {
+		t.Error("wheel position lost")
+	}
+	c.MustResume()
+	now = clock.Add(time.Minute)
+	c.tick(SlotSinceEpoch(now, c.interval))
+	time.Sleep(time.Millisecond * 10)
+	// the default misfire policy skips the gap
+	if runs.Load() != 1 {
+		t.Error("runs", runs.Load())
+	}
+	if err := c.Resume(); err == nil {
+		t.Error("expected err")
+	}
+}
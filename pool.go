@@ -0,0 +1,290 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// BackPressure decides what a full worker pool queue does with a new run.
+type BackPressure uint8
+
+const (
+	// BackPressureBlock blocks the wheel until the queue has room, the default.
+	BackPressureBlock BackPressure = iota
+	// BackPressureDropOldest drops the run waiting longest.
+	BackPressureDropOldest
+	// BackPressureDropNew drops the new run.
+	BackPressureDropNew
+)
+
+func (b BackPressure) String() string {
+	switch b {
+	case BackPressureBlock:
+		return "block"
+	case BackPressureDropOldest:
+		return "drop oldest"
+	case BackPressureDropNew:
+		return "drop new"
+	default:
+		return "unknown"
+	}
+}
+
+// PoolStats are the metrics of the worker pool, latency is the time a run
+// waits in the queue.
+type PoolStats struct {
+	Workers    int
+	QueueSize  int
+	QueueDepth int
+	Dispatched uint64
+	Dropped    uint64
+	AvgLatency time.Duration
+	MaxLatency time.Duration
+}
+
+type task struct {
+	job      *Job
+	run      *run
+	ctx      context.Context
+	priority int
+	enqueued time.Time
+}
+
+type pool struct {
+	locker       sync.Mutex
+	notEmpty     *sync.Cond
+	notFull      *sync.Cond
+	queue        []*task
+	work         func(*task)
+	workers      int
+	started      int
+	closed       bool
+	size         int
+	backPressure BackPressure
+	aging        time.Duration
+	dispatched   uint64
+	dropped      uint64
+	latency      time.Duration
+	maxLatency   time.Duration
+}
+
+func newPool(workers int, size int, backPressure BackPressure, aging time.Duration, work func(*task)) (p *pool) {
+	if size < 1 {
+		size = workers
+	}
+	p = &pool{
+		workers:      workers,
+		size:         size,
+		backPressure: backPressure,
+		aging:        aging,
+		work:         work,
+	}
+	p.notEmpty = sync.NewCond(&p.locker)
+	p.notFull = sync.NewCond(&p.locker)
+	return
+}
+
+// worker runs the queued tasks, it exits once the pool is closed and the
+// queue is drained.
+func (p *pool) worker() {
+	for {
+		t := p.pop()
+		if t == nil {
+			return
+		}
+		p.work(t)
+	}
+}
+
+func (p *pool) open() {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+
+	p.closed = false
+}
+
+// close lets the idle workers exit, a later push starts them again.
+func (p *pool) close() {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+
+	p.closed = true
+	p.notEmpty.Broadcast()
+}
+
+// push queues the task, it returns the task dropped by the back pressure.
+func (p *pool) push(t *task) (dropped *task) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+
+	for len(p.queue) >= p.size {
+		switch p.backPressure {
+		case BackPressureDropNew:
+			p.dropped++
+			dropped = t
+			return
+		case BackPressureDropOldest:
+			p.dropped++
+			dropped = p.queue[0]
+			p.queue = p.queue[1:]
+		default:
+			p.notFull.Wait()
+		}
+	}
+
+	t.enqueued = time.Now()
+	p.queue = append(p.queue, t)
+	if p.started < p.workers {
+		p.started++
+		go p.worker()
+	}
+	p.notEmpty.Signal()
+	return
+}
+
+func (p *pool) pop() (t *task) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+
+	for len(p.queue) == 0 {
+		if p.closed {
+			p.started--
+			return
+		}
+		p.notEmpty.Wait()
+	}
+	now := time.Now()
+	i := p.next(now)
+	t = p.queue[i]
+	copy(p.queue[i:], p.queue[i+1:])
+	p.queue[len(p.queue)-1] = nil
+	p.queue = p.queue[:len(p.queue)-1]
+	p.notFull.Signal()
+
+	latency := now.Sub(t.enqueued)
+	p.dispatched++
+	p.latency += latency
+	if latency > p.maxLatency {
+		p.maxLatency = latency
+	}
+	return
+}
+
+// next returns the index of the task with the highest priority, the oldest
+// first among equals. A task gains one priority every aging it waits, so
+// low priority tasks are not starved.
+func (p *pool) next(now time.Time) (i int) {
+	best := 0
+	for k, t := range p.queue {
+		priority := t.priority
+		if p.aging > 0 {
+			priority += int(now.Sub(t.enqueued) / p.aging)
+		}
+		if k == 0 || priority > best {
+			i = k
+			best = priority
+		}
+	}
+	return
+}
+
+func (p *pool) stats() (stats PoolStats) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+
+	stats = PoolStats{
+		Workers:    p.workers,
+		QueueSize:  p.size,
+		QueueDepth: len(p.queue),
+		Dispatched: p.dispatched,
+		Dropped:    p.dropped,
+		MaxLatency: p.maxLatency,
+	}
+	if p.dispatched > 0 {
+		stats.AvgLatency = p.latency / time.Duration(p.dispatched)
+	}
+	return
+}
+
+// PoolStats returns the metrics of the worker pool, zero without WithWorkerPool.
+func (c *Cron) PoolStats() (stats PoolStats) {
+	if c.pool == nil {
+		return
+	}
+	return c.pool.stats()
+}
+
+// work runs the task, then the runs the overlap policy queued behind it. Its
+// place in the concurrency group goes to the task held back first, which the
+// worker runs next.
+func (c *Cron) work(t *task) {
+	for t != nil {
+		c.execute(t)
+		next := t.job.group.release()
+		c.wg.Done()
+		t = next
+	}
+}
+
+func (c *Cron) execute(t *task) {
+	r := t.run
+	if err := t.ctx.Err(); err != nil {
+		// queued before the cron stopped
+		c.logger.Info("job skipped, cron stopped:", t.job.id)
+		c.skip(t.job, r, err)
+		t.job.release(r, true)
+		return
+	}
+	for {
+		err := c.runJob(t.job, r)
+		switch {
+		case errors.Is(err, ErrRunSkipped):
+			// a skipped run neither retries nor triggers the dependents
+			r.info.handle.finish(ErrRunSkipped)
+		case err == nil || !c.scheduleRetry(t.job, r.info, err):
+			r.info.handle.finish(err)
+			c.trigger(t.job, r.info, err)
+		}
+		info, ok := t.job.release(r, t.ctx.Err() != nil)
+		if !ok {
+			return
+		}
+		r = t.job.newRun(t.ctx, info)
+	}
+}
+
+// drop gives up a task the pool had no room for, its place in the
+// concurrency group goes to the task held back first. The runs the overlap
+// policy queued behind it are kept.
+func (c *Cron) drop(t *task) {
+	c.logger.Error("job dropped, worker pool full:", t.job.id)
+	next := c.skipTask(t, errors.New("worker pool full"))
+	if held := t.job.group.release(); held != nil {
+		c.hand(held)
+	}
+	if next != nil {
+		c.run(next)
+	}
+}
+
+// skipTask gives up the task before it runs, it returns the task
+// of the run the overlap policy queued behind it if any.
+func (c *Cron) skipTask(t *task, reason error) (next *task) {
+	defer c.wg.Done()
+
+	c.skip(t.job, t.run, reason)
+	info, ok := t.job.release(t.run, false)
+	if !ok {
+		return
+	}
+	return c.newTask(t.job, t.ctx, info)
+}
+
+// skip gives up a run that never started.
+func (c *Cron) skip(job *Job, r *run, reason error) {
+	job.endRun(r)
+	c.emitJob(EventJobSkipped, job, r.info, reason)
+	r.info.handle.finish(ErrRunSkipped)
+}
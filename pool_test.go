@@ -0,0 +1,195 @@
+package cron
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithWorkerPool(t *testing.T) {
+	var running, max atomic.Int32
+	c := New(WithWorkerPool(2, 10))
+	id := c.MustAddJob("every 1 minute", func() {
+		n := running.Add(1)
+		for m := max.Load(); n > m && !max.CompareAndSwap(m, n); m = max.Load() {
+		}
+		time.Sleep(time.Millisecond * 5)
+		running.Add(-1)
+	})
+	c.locker.Lock()
+	for i := 0; i < 10; i++ {
+		c.dispatch(c.jobs[id-1], time.Now())
+	}
+	c.unlock()
+	c.wg.Wait()
+
+	stats := c.PoolStats()
+	t.Logf("%+v", stats)
+	if stats.Dispatched != 10 || stats.Workers != 2 || stats.QueueDepth != 0 {
+		t.Errorf("%+v", stats)
+	}
+	if max.Load() > 2 {
+		t.Error("max concurrency", max.Load())
+	}
+}
+
+func testBackPressure(t *testing.T, backPressure BackPressure) (ran []time.Time, stats PoolStats) {
+	c := New(WithWorkerPool(1, 1), WithBackPressure(backPressure))
+	started := make(chan time.Time, 3)
+	release := make(chan struct{})
+	id := c.MustAddContextJob("every 1 minute", func(ctx context.Context) error {
+		info, _ := JobInfoFromContext(ctx)
+		started <- info.Scheduled
+		<-release
+		return nil
+	})
+	base := time.Now()
+	c.locker.Lock()
+	c.dispatch(c.jobs[id-1], base)
+	c.unlock()
+	ran = append(ran, <-started)
+	c.locker.Lock()
+	c.dispatch(c.jobs[id-1], base.Add(time.Minute))
+	c.dispatch(c.jobs[id-1], base.Add(time.Minute*2))
+	c.unlock()
+	close(release)
+	c.wg.Wait()
+	close(started)
+	for v := range started {
+		ran = append(ran, v)
+	}
+	stats = c.PoolStats()
+	return
+}
+
+func TestWithBackPressure(t *testing.T) {
+	ran, stats := testBackPressure(t, BackPressureDropNew)
+	if len(ran) != 2 || ran[1].Sub(ran[0]) != time.Minute || stats.Dropped != 1 {
+		t.Error("drop new", ran, stats.Dropped)
+	}
+
+	ran, stats = testBackPressure(t, BackPressureDropOldest)
+	if len(ran) != 2 || ran[1].Sub(ran[0]) != time.Minute*2 || stats.Dropped != 1 {
+		t.Error("drop oldest", ran, stats.Dropped)
+	}
+}
+
+func TestWithWorkerPool_CallbackCallsCron(t *testing.T) {
+	c := New(WithWorkerPool(1, 1))
+	var ids []uint32
+	for i := 0; i < 6; i++ {
+		ids = append(ids, c.MustAddJob("every 1 minute", func() {
+			_, _ = c.GetJob(1)
+			time.Sleep(time.Millisecond)
+		}))
+	}
+
+	// the full queue blocks the wheel, but not the callbacks calling the cron
+	done := make(chan struct{})
+	go func() {
+		c.locker.Lock()
+		for _, id := range ids {
+			c.dispatch(c.jobs[id-1], time.Now())
+		}
+		c.unlock()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("deadlock")
+	}
+	c.wg.Wait()
+	if stats := c.PoolStats(); stats.Dispatched != 6 {
+		t.Errorf("%+v", stats)
+	}
+}
+
+func TestWithWorkerPool_Stop(t *testing.T) {
+	c := New(WithWorkerPool(2, 10))
+	id := c.MustAddJob("every 1 minute", func() {})
+	c.Start()
+	c.locker.Lock()
+	c.dispatch(c.jobs[id-1], time.Now())
+	c.dispatch(c.jobs[id-1], time.Now())
+	c.unlock()
+	c.wg.Wait()
+	c.Stop()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		c.pool.locker.Lock()
+		started := c.pool.started
+		c.pool.locker.Unlock()
+		if started == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("workers", started)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestWithWorkerPool_DropKeepsQueued(t *testing.T) {
+	c := New(WithWorkerPool(1, 1), WithBackPressure(BackPressureDropNew))
+	started := make(chan struct{})
+	release := make(chan struct{})
+	blocker := c.MustAddJob("@manual", func() {
+		close(started)
+		<-release
+	})
+	waiting := c.MustAddJob("@manual", func() {})
+	id := c.MustAddJob("@manual", func() {}, JobOverlap(OverlapQueue))
+
+	c.locker.Lock()
+	c.dispatch(c.jobs[blocker-1], time.Now())
+	c.unlock()
+	<-started
+	c.locker.Lock()
+	c.dispatch(c.jobs[waiting-1], time.Now())
+	c.dispatch(c.jobs[id-1], time.Now())
+	c.dispatch(c.jobs[id-1], time.Now())
+	c.unlock()
+	close(release)
+	c.wg.Wait()
+
+	// the queued run is dispatched on its own rather than skipped with the dropped one
+	if skipped := c.jobs[id-1].Skipped(); skipped != 0 {
+		t.Error("skipped", skipped)
+	}
+	if stats := c.PoolStats(); stats.Dropped != 2 {
+		t.Errorf("%+v", stats)
+	}
+}
+
+func TestWithWorkerPool_StopSkipsQueued(t *testing.T) {
+	c := New(WithWorkerPool(1, 10))
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var runs atomic.Int32
+	blocker := c.MustAddJob("@manual", func() {
+		close(started)
+		<-release
+	})
+	id := c.MustAddJob("@manual", func() {
+		runs.Add(1)
+	})
+	c.MustStart()
+
+	c.locker.Lock()
+	c.dispatch(c.jobs[blocker-1], time.Now())
+	c.unlock()
+	<-started
+	c.locker.Lock()
+	c.dispatch(c.jobs[id-1], time.Now())
+	c.unlock()
+	c.MustStop()
+	close(release)
+	c.wg.Wait()
+
+	if runs.Load() != 0 {
+		t.Error("runs after stop", runs.Load())
+	}
+}
@@ -0,0 +1,69 @@
+package cron
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestJobPriority(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.Local)
+	clock := &fakeNow{now: now}
+	c := New(WithNowFunc(clock.Now), WithWorkerPool(1, 10))
+	release := make(chan struct{})
+	started := make(chan struct{})
+	blocker := c.MustAddJob("@manual", func() {
+		close(started)
+		<-release
+	})
+	var locker sync.Mutex
+	var order []int
+	for _, priority := range []int{0, 5, -1, 10} {
+		priority := priority
+		id := c.MustAddJob("every 1 minute", func() {
+			locker.Lock()
+			order = append(order, priority)
+			locker.Unlock()
+		}, JobPriority(priority))
+		if err := c.jobs[id-1].Next(c.interval); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	// the pool is busy, so the order is the one of the queue
+	c.locker.Lock()
+	c.dispatch(c.jobs[blocker-1], now)
+	c.unlock()
+	<-started
+	now = clock.Add(time.Minute)
+	c.locker.Lock()
+	c.tick(SlotSinceEpoch(now, c.interval))
+	c.unlock()
+	close(release)
+	c.wg.Wait()
+
+	locker.Lock()
+	defer locker.Unlock()
+	if len(order) != 4 || order[0] != 10 || order[1] != 5 || order[2] != 0 || order[3] != -1 {
+		t.Error("order", order)
+	}
+}
+
+func TestPool_Aging(t *testing.T) {
+	p := &pool{aging: time.Second}
+	now := time.Now()
+	p.queue = []*task{
+		{priority: 0, enqueued: now.Add(-time.Second * 3)},
+		{priority: 2, enqueued: now},
+		{priority: 2, enqueued: now},
+	}
+	if i := p.next(now); i != 0 {
+		t.Error("starved", i)
+	}
+
+	p.aging = -1
+	if i := p.next(now); i != 1 {
+		t.Error("strict", i)
+	}
+}
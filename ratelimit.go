@@ -0,0 +1,155 @@
+package cron
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrRateLimited is the error of the skipped event of a run the rate limit
+// held back longer than its max delay.
+var ErrRateLimited = errors.New("rate limited")
+
+// RateLimit is a token bucket on the scheduled runs: Rate runs per second
+// with bursts of Burst. A run over the limit waits on the following slots,
+// at most MaxDelay, then it is skipped; without MaxDelay it is skipped at once.
+type RateLimit struct {
+	Rate     float64
+	Burst    int
+	MaxDelay time.Duration
+}
+
+type bucket struct {
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(limit RateLimit) *bucket {
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+	return &bucket{
+		limit:  limit,
+		tokens: float64(limit.Burst),
+	}
+}
+
+// refill adds the tokens earned since the last refill.
+func (b *bucket) refill(now time.Time) {
+	if !b.last.IsZero() && now.After(b.last) {
+		b.tokens += now.Sub(b.last).Seconds() * b.limit.Rate
+		if b.tokens > float64(b.limit.Burst) {
+			b.tokens = float64(b.limit.Burst)
+		}
+	}
+	b.last = now
+}
+
+// delayed is a run the rate limit holds back.
+type delayed struct {
+	job      *Job
+	info     JobInfo
+	since    time.Time
+	deadline time.Time
+}
+
+// buckets returns the rate limits of the job, the one of the cron first.
+func (c *Cron) buckets(job *Job) (buckets []*bucket) {
+	if c.rateLimit != nil {
+		buckets = append(buckets, c.rateLimit)
+	}
+	for _, tag := range job.tags {
+		if b, ok := c.tagRateLimits[tag]; ok {
+			buckets = append(buckets, b)
+		}
+	}
+	return
+}
+
+// take takes a token of every bucket, or none when one is empty.
+func take(buckets []*bucket, now time.Time) bool {
+	for _, b := range buckets {
+		b.refill(now)
+		if b.tokens < 1 {
+			return false
+		}
+	}
+	for _, b := range buckets {
+		b.tokens--
+	}
+	return true
+}
+
+// limit reports whether the run may start now, otherwise it waits on the
+// wheel. The caller holds c.locker.
+func (c *Cron) limit(job *Job, info JobInfo) (ok bool) {
+	buckets := c.buckets(job)
+	now := c.nowFunc()
+	if len(buckets) == 0 || take(buckets, now) {
+		return true
+	}
+
+	maxDelay := buckets[0].limit.MaxDelay
+	for _, b := range buckets[1:] {
+		if b.limit.MaxDelay < maxDelay {
+			maxDelay = b.limit.MaxDelay
+		}
+	}
+	if maxDelay <= 0 {
+		c.logger.Error("job skipped, rate limited:", job.id)
+		c.postJob(EventJobSkipped, job, info, ErrRateLimited)
+		return
+	}
+	c.delayed = append(c.delayed, &delayed{
+		job:      job,
+		info:     info,
+		since:    now,
+		deadline: now.Add(maxDelay),
+	})
+	c.logger.Info("job delayed, rate limited:", job.id)
+	return
+}
+
+// dropDelayed gives up the runs the rate limit holds back, the cron stopped.
+func (c *Cron) dropDelayed() {
+	c.locker.Lock()
+	delayed := c.delayed
+	c.delayed = nil
+	for _, d := range delayed {
+		c.logger.Info("job delayed run dropped, cron stopped:", d.job.id)
+		c.postJob(EventJobSkipped, d.job, d.info, ErrRunSkipped)
+		d.info.handle.finish(ErrRunSkipped)
+	}
+	c.unlock()
+}
+
+// fireDelayed dispatches the runs the rate limit held back, oldest first.
+// The caller holds c.locker.
+func (c *Cron) fireDelayed() {
+	if len(c.delayed) == 0 {
+		return
+	}
+
+	now := c.nowFunc()
+	delayed := c.delayed[:0]
+	for _, d := range c.delayed {
+		if d.job.Deleted {
+			continue
+		}
+		if take(c.buckets(d.job), now) {
+			d.info.Delay = now.Sub(d.since)
+			c.submit(d.job, d.info)
+			continue
+		}
+		if now.Before(d.deadline) {
+			delayed = append(delayed, d)
+			continue
+		}
+		c.logger.Error("job skipped, rate limited:", d.job.id)
+		c.postJob(EventJobSkipped, d.job, d.info, ErrRateLimited)
+	}
+	for i := len(delayed); i < len(c.delayed); i++ {
+		c.delayed[i] = nil
+	}
+	c.delayed = delayed
+}
@@ -0,0 +1,89 @@
+package cron
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 59, 0, 0, time.Local)
+	clock := &fakeNow{now: now}
+	c := New(WithNowFunc(clock.Now), WithRateLimit(RateLimit{Rate: 1.0 / 60, Burst: 1, MaxDelay: time.Minute * 2}))
+	var ids []uint32
+	for i := 0; i < 3; i++ {
+		id := c.MustAddJob("0 * * * *", func() {})
+		if err := c.jobs[id-1].Next(c.interval); err != nil {
+			t.Error(err)
+			return
+		}
+		ids = append(ids, id)
+	}
+	var locker sync.Mutex
+	var delays []time.Duration
+	c.AddListener(func(event Event) {
+		if event.Type == EventJobStarted {
+			locker.Lock()
+			delays = append(delays, event.Delay)
+			locker.Unlock()
+		}
+	})
+
+	for i := 0; i < 3; i++ {
+		now = clock.Add(time.Minute)
+		c.tick(SlotSinceEpoch(now, c.interval))
+		c.wg.Wait()
+	}
+
+	locker.Lock()
+	defer locker.Unlock()
+	if len(delays) != 3 || delays[0] != 0 || delays[1] != time.Minute || delays[2] != time.Minute*2 {
+		t.Error("delays", delays)
+	}
+	records, err := c.History(ids[2])
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(records) != 1 || records[0].Delay != time.Minute*2 {
+		t.Error("records", records)
+	}
+}
+
+func TestWithTagRateLimit(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 59, 0, 0, time.Local)
+	clock := &fakeNow{now: now}
+	c := New(WithNowFunc(clock.Now), WithTagRateLimit("bulk", RateLimit{Rate: 1, Burst: 2}))
+	var runs, limited atomic.Int32
+	c.AddListener(func(event Event) {
+		if event.Type == EventJobSkipped && errors.Is(event.Err, ErrRateLimited) {
+			limited.Add(1)
+		}
+	})
+	for i := 0; i < 6; i++ {
+		var options []JobOptions
+		if i%2 == 0 {
+			options = append(options, JobTags("bulk"))
+		}
+		id := c.MustAddJob("0 * * * *", func() {
+			runs.Add(1)
+		}, options...)
+		if err := c.jobs[id-1].Next(c.interval); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		now = clock.Add(time.Minute)
+		c.locker.Lock()
+		c.tick(SlotSinceEpoch(now, c.interval))
+		c.unlock()
+		c.wg.Wait()
+	}
+	if runs.Load() != 5 || limited.Load() != 1 {
+		t.Error("runs", runs.Load(), "limited", limited.Load())
+	}
+}
@@ -0,0 +1,158 @@
+package cron
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Backoff returns the delay before the retry following the failed attempt.
+type Backoff func(attempt int) time.Duration
+
+// ConstantBackoff waits the same delay before every retry.
+func ConstantBackoff(delay time.Duration) Backoff {
+	return func(int) time.Duration {
+		return delay
+	}
+}
+
+// ExponentialBackoff doubles the delay from base after every attempt, at most max.
+func ExponentialBackoff(base time.Duration, max time.Duration) Backoff {
+	return func(attempt int) (delay time.Duration) {
+		delay = base
+		for i := 1; i < attempt && delay < max; i++ {
+			delay *= 2
+		}
+		if delay > max {
+			delay = max
+		}
+		return
+	}
+}
+
+// DecorrelatedJitterBackoff picks a random delay between base and three
+// times the previous one, at most max.
+func DecorrelatedJitterBackoff(base time.Duration, max time.Duration) Backoff {
+	return func(attempt int) (delay time.Duration) {
+		delay = base
+		for i := 0; i < attempt; i++ {
+			delay = base + time.Duration(rand.Int63n(int64(delay*3-base)+1))
+			if delay > max {
+				delay = max
+			}
+		}
+		return
+	}
+}
+
+// RetryPolicy retries a failed (error or panic) run on the wheel.
+type RetryPolicy struct {
+	// MaxAttempts counts the first run too.
+	MaxAttempts int
+	// Backoff defaults to one interval.
+	Backoff Backoff
+	// Retryable reports whether the error is worth a retry, nil retries every error.
+	Retryable func(err error) bool
+	// OnFailure is called when the last attempt failed or the error is not retryable.
+	OnFailure func(info JobInfo, err error)
+}
+
+// retry is a retry waiting on the wheel.
+type retry struct {
+	job   *Job
+	slot  uint64
+	info  JobInfo
+	fires uint64
+}
+
+// scheduleRetry puts the next attempt of the failed run on the wheel, it
+// reports false when there is none.
+func (c *Cron) scheduleRetry(job *Job, info JobInfo, err error) (ok bool) {
+	policy := job.retry
+	if policy == nil {
+		return
+	}
+
+	if info.Attempt >= policy.MaxAttempts || (policy.Retryable != nil && !policy.Retryable(err)) {
+		c.logger.Errorf("job %d failed after %d attempts: %v", job.id, info.Attempt, err)
+		if policy.OnFailure != nil {
+			policy.OnFailure(info, err)
+		}
+		return
+	}
+
+	delay := c.interval
+	if policy.Backoff != nil {
+		delay = policy.Backoff(info.Attempt)
+	}
+	now := c.nowFunc()
+	slot := SlotSinceEpoch(now.Add(delay), c.interval)
+	if current := SlotSinceEpoch(now, c.interval); slot <= current {
+		slot = current + 1
+	}
+
+	c.retryLocker.Lock()
+	if state := c.State(); state == StateStopping || state == StateStopped {
+		// stop drained the wheel already
+		c.retryLocker.Unlock()
+		c.logger.Errorf("job %d failed, no retry, cron stopped: %v", job.id, err)
+		return
+	}
+	info.Attempt++
+	c.retries = append(c.retries, &retry{
+		job:   job,
+		slot:  slot,
+		info:  info,
+		fires: job.fires.Load(),
+	})
+	c.retryLocker.Unlock()
+	c.logger.Infof("job %d retry %d in %s: %v", job.id, info.Attempt, delay, err)
+	ok = true
+	return
+}
+
+// dueRetries takes the retries due at the slot off the wheel.
+func (c *Cron) dueRetries(slot uint64) (due []*retry) {
+	c.retryLocker.Lock()
+	defer c.retryLocker.Unlock()
+
+	retries := c.retries[:0]
+	for _, r := range c.retries {
+		if r.slot <= slot {
+			due = append(due, r)
+		} else {
+			retries = append(retries, r)
+		}
+	}
+	c.retries = retries
+	return
+}
+
+// dropRetries gives up the retries waiting on the wheel, the cron stopped.
+func (c *Cron) dropRetries() {
+	c.retryLocker.Lock()
+	retries := c.retries
+	c.retries = nil
+	c.retryLocker.Unlock()
+
+	for _, r := range retries {
+		c.logger.Info("job retry dropped, cron stopped:", r.job.id)
+		r.info.handle.finish(ErrRunSkipped)
+	}
+}
+
+// fireRetries dispatches the retries of the slot. A retry is dropped when the
+// job runs regularly in the same slot or already ran since the failure.
+func (c *Cron) fireRetries(slot uint64) {
+	for _, r := range c.dueRetries(slot) {
+		if r.job.Deleted {
+			r.info.handle.finish(ErrRunSkipped)
+			continue
+		}
+		if r.job.slot == slot || r.job.fires.Load() != r.fires {
+			c.logger.Info("job retry superseded by the next run:", r.job.id)
+			r.info.handle.finish(ErrRunSkipped)
+			continue
+		}
+		c.submit(r.job, r.info)
+	}
+}
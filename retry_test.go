@@ -0,0 +1,139 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	if d := ConstantBackoff(time.Second)(3); d != time.Second {
+		t.Error("constant", d)
+	}
+
+	exponential := ExponentialBackoff(time.Second, time.Second*5)
+	for attempt, delay := range []time.Duration{time.Second, time.Second * 2, time.Second * 4, time.Second * 5} {
+		if d := exponential(attempt + 1); d != delay {
+			t.Error("exponential", attempt+1, d)
+		}
+	}
+
+	jitter := DecorrelatedJitterBackoff(time.Second, time.Minute)
+	for attempt := 1; attempt < 10; attempt++ {
+		if d := jitter(attempt); d < time.Second || d > time.Minute {
+			t.Error("decorrelated jitter", attempt, d)
+		}
+	}
+}
+
+func TestJobRetry(t *testing.T) {
+	now := time.Now().Truncate(time.Minute)
+	clock := &fakeNow{now: now}
+	c := New(WithNowFunc(clock.Now))
+	var attempts []int
+	var failed JobInfo
+	id, err := c.AddJob("0 0 1 1 *", func() {
+		panic("boom")
+	}, JobRetry(RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     ConstantBackoff(time.Minute * 2),
+		OnFailure: func(info JobInfo, err error) {
+			failed = info
+		},
+	}))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	job := c.jobs[id-1]
+
+	c.dispatch(job, now)
+	c.wg.Wait()
+	for i := 0; i < 6; i++ {
+		now = clock.Add(time.Minute)
+		if len(c.retries) > 0 && c.retries[0].slot == SlotSinceEpoch(now, c.interval) {
+			attempts = append(attempts, c.retries[0].info.Attempt)
+		}
+		c.tick(SlotSinceEpoch(now, c.interval))
+		c.wg.Wait()
+	}
+
+	if len(attempts) != 2 || attempts[0] != 2 || attempts[1] != 3 {
+		t.Error("attempts", attempts)
+	}
+	if failed.Attempt != 3 || failed.ID != id {
+		t.Errorf("%+v", failed)
+	}
+}
+
+func TestJobRetryNotRetryable(t *testing.T) {
+	c := New()
+	errFatal := errors.New("fatal")
+	failed := make(chan error, 1)
+	id := c.MustAddContextJob("every 1 minute", func(ctx context.Context) error {
+		return errFatal
+	}, JobRetry(RetryPolicy{
+		MaxAttempts: 3,
+		Retryable: func(err error) bool {
+			return !errors.Is(err, errFatal)
+		},
+		OnFailure: func(info JobInfo, err error) {
+			failed <- err
+		},
+	}))
+	c.dispatch(c.jobs[id-1], time.Now())
+	c.wg.Wait()
+	if err := <-failed; !errors.Is(err, errFatal) || len(c.retries) != 0 {
+		t.Error(err)
+	}
+}
+
+func TestJobRetrySuperseded(t *testing.T) {
+	now := time.Now().Truncate(time.Minute)
+	clock := &fakeNow{now: now}
+	c := New(WithNowFunc(clock.Now))
+	var runs int
+	id := c.MustAddJob("every 1 minute", func() {
+		runs++
+		panic("boom")
+	}, JobRetry(RetryPolicy{MaxAttempts: 3}))
+	job := c.jobs[id-1]
+	if err := job.Next(c.interval); err != nil {
+		t.Error(err)
+		return
+	}
+
+	c.dispatch(job, now)
+	c.wg.Wait()
+	now = clock.Add(time.Minute)
+	c.tick(SlotSinceEpoch(now, c.interval))
+	c.wg.Wait()
+	if runs != 2 {
+		t.Error("runs", runs)
+	}
+}
+
+func TestJobRetryRestart(t *testing.T) {
+	c := New()
+	var runs atomic.Int32
+	id := c.MustAddContextJob("@manual", func(ctx context.Context) error {
+		runs.Add(1)
+		return errors.New("failed")
+	}, JobRetry(RetryPolicy{MaxAttempts: 2, Backoff: ConstantBackoff(time.Hour)}))
+	c.MustStart()
+	defer c.MustStop()
+
+	c.MustRunNow(id)
+	c.wg.Wait()
+	c.MustRestart()
+
+	// the retry left on the wheel does not fire after the restart
+	c.retryLocker.Lock()
+	retries := len(c.retries)
+	c.retryLocker.Unlock()
+	if retries != 0 || runs.Load() != 1 {
+		t.Error("retries", retries, "runs", runs.Load())
+	}
+}
@@ -0,0 +1,100 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrRunSkipped is the result of a run that never ran: the overlap policy or
+// SkipIfStillRunning skipped it, the pool dropped it, the cron stopped first
+// or a regular run superseded its retry.
+var ErrRunSkipped = errors.New("run skipped")
+
+// RunHandle is the run started by RunNow.
+type RunHandle struct {
+	done chan struct{}
+	once sync.Once
+	err  error
+}
+
+func newRunHandle() *RunHandle {
+	return &RunHandle{done: make(chan struct{})}
+}
+
+// Done is closed when the run and its retries are over, a retry still
+// waiting when the cron stops ends with ErrRunSkipped.
+func (h *RunHandle) Done() <-chan struct{} {
+	return h.done
+}
+
+// Err is the result of the last attempt, valid once Done is closed.
+func (h *RunHandle) Err() error {
+	<-h.done
+	return h.err
+}
+
+// Wait waits for the result or ctx.
+func (h *RunHandle) Wait(ctx context.Context) (err error) {
+	select {
+	case <-h.done:
+		err = h.err
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	return
+}
+
+// finish records the result, a nil handle belongs to a scheduled run.
+func (h *RunHandle) finish(err error) {
+	if h == nil {
+		return
+	}
+	h.once.Do(func() {
+		h.err = err
+		close(h.done)
+	})
+}
+
+func (c *Cron) MustRunNow(id uint32) (handle *RunHandle) {
+	handle, _ = c.RunNow(id)
+	return
+}
+
+// RunNow runs the job now the same way as a scheduled run, through the
+// chain, the overlap policy, the retries and the events. The next scheduled
+// run is not moved. A paused job can be run.
+func (c *Cron) RunNow(id uint32) (handle *RunHandle, err error) {
+	if c == nil {
+		err = errors.New("cron nil")
+		c.logger.Error(err)
+		return
+	}
+
+	// holding the lifecycle keeps the cron running until the run is tracked
+	c.lifecycle.Lock()
+	defer c.lifecycle.Unlock()
+
+	if c.State() != StateRunning {
+		err = errors.New("cron not running")
+		c.logger.Error(err)
+		return
+	}
+
+	c.locker.Lock()
+	defer c.unlock()
+
+	job, err := c.lookup(id)
+	if err == nil && job.Deleted {
+		err = errors.New("job removed")
+	}
+	if err != nil {
+		c.logger.Error(err)
+		return
+	}
+
+	handle = newRunHandle()
+	c.logger.Info("job run now:", id)
+	c.submit(job, JobInfo{Scheduled: c.nowFunc(), Attempt: 1, CorrelationID: newCorrelationID(), handle: handle})
+	return
+}
@@ -0,0 +1,115 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCron_RunNow(t *testing.T) {
+	c := New()
+	id := c.MustAddContextJob("0 3 * * *", func(ctx context.Context) error {
+		return errors.New("failed")
+	})
+	if _, err := c.RunNow(id); err == nil {
+		t.Error("expected err, cron not running")
+	}
+
+	c.MustStart()
+	defer c.MustStop()
+	job := c.jobs[id-1]
+	c.locker.Lock()
+	slot := job.slot
+	c.locker.Unlock()
+
+	handle, err := c.RunNow(id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err = handle.Wait(ctx); err == nil || err.Error() != "failed" {
+		t.Error("result", err)
+	}
+
+	c.locker.Lock()
+	if job.slot != slot {
+		t.Error("next slot moved", job.slot, slot)
+	}
+	c.locker.Unlock()
+	if _, err = c.RunNow(id + 1); err == nil {
+		t.Error("expected err")
+	}
+}
+
+func TestCron_RunNowSkipped(t *testing.T) {
+	c := New()
+	release := make(chan struct{})
+	id := c.MustAddJob("0 3 * * *", func() {
+		<-release
+	}, JobOverlap(OverlapSkip))
+	c.MustStart()
+	defer c.MustStop()
+
+	first := c.MustRunNow(id)
+	second := c.MustRunNow(id)
+	if err := second.Err(); !errors.Is(err, ErrRunSkipped) {
+		t.Error("second", err)
+	}
+	close(release)
+	if err := first.Err(); err != nil {
+		t.Error("first", err)
+	}
+}
+
+func TestCron_RunNowRemoveJob(t *testing.T) {
+	c := New()
+	id := c.MustAddContextJob("0 3 * * *", func(ctx context.Context) error {
+		time.Sleep(time.Millisecond)
+		return nil
+	}, JobOverlap(OverlapQueue), JobQueueLimit(10))
+	c.MustStart()
+	defer c.MustStop()
+
+	// the queued runs start while the job is removed
+	for i := 0; i < 5; i++ {
+		if _, err := c.RunNow(id); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	c.MustRemoveJob(id)
+	c.wg.Wait()
+}
+
+func TestCron_RunNowRetryStop(t *testing.T) {
+	c := New()
+	id := c.MustAddContextJob("@manual", func(ctx context.Context) error {
+		return errors.New("failed")
+	}, JobRetry(RetryPolicy{MaxAttempts: 2, Backoff: ConstantBackoff(time.Hour)}))
+	c.MustStart()
+
+	handle, err := c.RunNow(id)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	c.wg.Wait()
+	select {
+	case <-handle.Done():
+		t.Error("done before the retry")
+	default:
+	}
+	c.MustStop()
+
+	select {
+	case <-handle.Done():
+	case <-time.After(time.Second):
+		t.Fatal("handle not finished")
+	}
+	if !errors.Is(handle.Err(), ErrRunSkipped) {
+		t.Error(handle.Err())
+	}
+}
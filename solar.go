@@ -0,0 +1,126 @@
+package cron
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var reSolar = regexp.MustCompile(`^@(sunrise|sunset|dawn|dusk)([+-][0-9a-z.]+)?\s+(-?\d+(?:\.\d+)?),\s*(-?\d+(?:\.\d+)?)$`)
+
+const (
+	julian1970 = 2440587.5
+	julian2000 = 2451545.0
+	// maxSolarDays bounds the search for the next event, long enough to
+	// leave any polar day or polar night.
+	maxSolarDays = 370
+)
+
+type solarEvent uint8
+
+const (
+	sunrise solarEvent = iota
+	sunset
+	dawn
+	dusk
+)
+
+// solar computes sun events with the sunrise equation, accurate to about a minute.
+type solar struct {
+	event     solarEvent
+	offset    time.Duration
+	latitude  float64
+	longitude float64
+}
+
+// newSolar parses specs like "@sunset+30m 31.23,121.47".
+func newSolar(spec string) (s *solar, err error) {
+	r := reSolar.FindStringSubmatch(strings.TrimSpace(spec))
+	if len(r) != 5 {
+		err = errors.New("parse err")
+		return
+	}
+
+	s = &solar{}
+	switch r[1] {
+	case "sunrise":
+		s.event = sunrise
+	case "sunset":
+		s.event = sunset
+	case "dawn":
+		s.event = dawn
+	case "dusk":
+		s.event = dusk
+	}
+
+	if r[2] != "" {
+		if s.offset, err = time.ParseDuration(r[2]); err != nil {
+			err = errors.New("parse err")
+			return
+		}
+	}
+
+	if s.latitude, err = strconv.ParseFloat(r[3], 64); err != nil || s.latitude < -90 || s.latitude > 90 {
+		err = errors.New("latitude err")
+		return
+	}
+	if s.longitude, err = strconv.ParseFloat(r[4], 64); err != nil || s.longitude < -180 || s.longitude > 180 {
+		err = errors.New("longitude err")
+		return
+	}
+	return
+}
+
+// at returns the event of the given julian cycle, ok is false on polar day or night.
+func (s *solar) at(n float64) (t time.Time, ok bool) {
+	rad := math.Pi / 180
+
+	meanNoon := n - s.longitude/360
+	anomaly := math.Mod(357.5291+0.98560028*meanNoon, 360)
+	center := 1.9148*math.Sin(anomaly*rad) + 0.02*math.Sin(2*anomaly*rad) + 0.0003*math.Sin(3*anomaly*rad)
+	ecliptic := math.Mod(anomaly+center+180+102.9372, 360)
+	transit := julian2000 + meanNoon + 0.0053*math.Sin(anomaly*rad) - 0.0069*math.Sin(2*ecliptic*rad)
+	declination := math.Asin(math.Sin(ecliptic*rad) * math.Sin(23.4397*rad))
+
+	altitude := -0.833
+	if s.event == dawn || s.event == dusk {
+		altitude = -6
+	}
+	cosHour := (math.Sin(altitude*rad) - math.Sin(s.latitude*rad)*math.Sin(declination)) /
+		(math.Cos(s.latitude*rad) * math.Cos(declination))
+	if cosHour < -1 || cosHour > 1 {
+		return
+	}
+	hour := math.Acos(cosHour) / rad
+
+	julian := transit - hour/360
+	if s.event == sunset || s.event == dusk {
+		julian = transit + hour/360
+	}
+
+	t = time.Unix(0, int64((julian-julian1970)*86400*float64(time.Second))).Add(s.offset)
+	ok = true
+	return
+}
+
+// next returns the first event, truncated to the interval, after the given time.
+func (s *solar) next(after time.Time, interval time.Duration) (next time.Time, err error) {
+	n := math.Floor(float64(after.Unix())/86400+julian1970-julian2000) - 1
+	for i := 0; i < maxSolarDays; i++ {
+		t, ok := s.at(n + float64(i))
+		if !ok {
+			continue
+		}
+		t = t.Truncate(interval).In(after.Location())
+		if t.After(after) {
+			next = t
+			return
+		}
+	}
+
+	err = errors.New("no match")
+	return
+}
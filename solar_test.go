@@ -0,0 +1,83 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSolar_New(t *testing.T) {
+	s, err := newSolar("@sunset+30m 31.23,121.47")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if s.event != sunset || s.offset != 30*time.Minute {
+		t.Errorf("%+v", s)
+	}
+
+	for _, spec := range []string{"@noon 31.23,121.47", "@sunset", "@sunrise 91,0", "@dusk+x 0,0"} {
+		if _, err = newSolar(spec); err == nil {
+			t.Error("expected err:", spec)
+		}
+	}
+}
+
+func TestSolar_Next(t *testing.T) {
+	shanghai := time.FixedZone("CST", 8*3600)
+	s, err := newSolar("@sunset 31.23,121.47")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	after := time.Date(2023, 6, 21, 12, 0, 0, 0, shanghai)
+	next, err := s.next(after, time.Minute)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	t.Log(next) // 19:01
+	if next.Hour() != 19 || next.Day() != 21 {
+		t.Error("sunset", next)
+	}
+
+	s.offset = -30 * time.Minute
+	prev := next
+	next, _ = s.next(after, time.Minute)
+	if prev.Sub(next) != 30*time.Minute {
+		t.Error("offset", next)
+	}
+}
+
+func TestSolar_NextPolar(t *testing.T) {
+	s, err := newSolar("@sunrise 78.22,15.65")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	after := time.Date(2023, 6, 21, 0, 0, 0, 0, time.UTC)
+	next, err := s.next(after, time.Minute)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	t.Log(next) // polar day until late august
+	if next.Month() != time.August {
+		t.Error("polar day", next)
+	}
+}
+
+func TestJob_InitSolar(t *testing.T) {
+	job := &Job{}
+	if err := job.Init("@dawn-15m 31.23,121.47", time.Minute, false); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := job.Next(time.Minute); err != nil {
+		t.Error(err)
+		return
+	}
+	t.Log(job.nextTime)
+	if !job.nextTime.After(time.Now()) {
+		t.Error("next time", job.nextTime)
+	}
+}
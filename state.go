@@ -0,0 +1,30 @@
+package cron
+
+// State is the lifecycle state of a Cron:
+// created -> starting -> running -> stopping -> stopped, and stopped -> starting again.
+type State int32
+
+const (
+	StateCreated State = iota
+	StateStarting
+	StateRunning
+	StateStopping
+	StateStopped
+)
+
+func (s State) String() string {
+	switch s {
+	case StateCreated:
+		return "created"
+	case StateStarting:
+		return "starting"
+	case StateRunning:
+		return "running"
+	case StateStopping:
+		return "stopping"
+	case StateStopped:
+		return "stopped"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,88 @@
+package cron
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// watch starts the timeout of the run when it starts. A run exceeding it has
+// its context cancelled and is recorded as timed out, once the grace period
+// is over too the overlap policy no longer counts it as running.
+func (c *Cron) watch(job *Job, r *run) (stop func()) {
+	if job.timeout <= 0 {
+		return func() {}
+	}
+
+	var cancel context.CancelFunc
+	r.ctx, cancel = context.WithTimeout(r.ctx, job.timeout)
+
+	var locker sync.Mutex
+	var grace *time.Timer
+	timer := time.AfterFunc(job.timeout, func() {
+		if job.expire(r) {
+			c.logger.Errorf("job %d timed out after %s", job.id, job.timeout)
+		}
+
+		locker.Lock()
+		defer locker.Unlock()
+		grace = time.AfterFunc(job.timeoutGrace, func() {
+			c.detach(job, r)
+		})
+	})
+
+	return func() {
+		cancel()
+		timer.Stop()
+		locker.Lock()
+		defer locker.Unlock()
+		if grace != nil {
+			grace.Stop()
+		}
+	}
+}
+
+// expire marks the run timed out, it reports false when it already was.
+func (j *Job) expire(r *run) bool {
+	if !r.timedOut.CompareAndSwap(false, true) {
+		return false
+	}
+	j.timeouts.Add(1)
+	return true
+}
+
+// detach frees the overlap slot of a run still hanging after the grace
+// period, the next queued run if any is started.
+func (c *Cron) detach(job *Job, r *run) {
+	info, ok, detached := job.detach(r)
+	if !detached {
+		return
+	}
+
+	c.logger.Errorf("job %d still running %s after the timeout, released", job.id, job.timeoutGrace)
+	if ok {
+		c.enqueue(job, r.parent, info)
+	}
+}
+
+// detach marks the run detached, like release it hands back the next queued run.
+func (j *Job) detach(r *run) (info JobInfo, ok bool, detached bool) {
+	j.locker.Lock()
+	defer j.locker.Unlock()
+
+	if _, running := j.runs[r]; !running {
+		return
+	}
+	r.detached = true
+	detached = true
+
+	if len(j.pending) == 0 {
+		j.active--
+		return
+	}
+
+	info = j.pending[0]
+	j.pending = j.pending[1:]
+	ok = true
+	return
+}
@@ -0,0 +1,38 @@
+package cron
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestJobTimeoutGrace(t *testing.T) {
+	var runs atomic.Int32
+	c := New(WithTimeout(time.Millisecond*20), WithTimeoutGrace(time.Millisecond*20))
+	id := c.MustAddJob("every 1 minute", func() {
+		runs.Add(1)
+		// ignores its context
+		time.Sleep(time.Millisecond * 200)
+	}, JobOverlap(OverlapSkip))
+	job := c.jobs[id-1]
+	if job.timeout != time.Millisecond*20 {
+		t.Error("default timeout", job.timeout)
+	}
+
+	c.dispatch(job, time.Now())
+	time.Sleep(time.Millisecond * 10)
+	c.dispatch(job, time.Now())
+	if job.Skipped() != 1 {
+		t.Error("skipped before the timeout", job.Skipped())
+	}
+
+	time.Sleep(time.Millisecond * 50)
+	c.dispatch(job, time.Now())
+	c.wg.Wait()
+	if runs.Load() != 2 || job.Skipped() != 1 {
+		t.Error("runs", runs.Load(), job.Skipped())
+	}
+	if job.timeouts.Load() != 2 {
+		t.Error("timeouts", job.timeouts.Load())
+	}
+}
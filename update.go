@@ -0,0 +1,129 @@
+package cron
+
+import (
+	"errors"
+)
+
+func (c *Cron) MustUpdateJob(id uint32, spec string) {
+	_ = c.UpdateJob(id, spec)
+}
+
+// UpdateJob reparses the spec of the job and moves it to its new slot, the
+// job keeps its id, options and state. A completed job cannot be updated.
+func (c *Cron) UpdateJob(id uint32, spec string) (err error) {
+	if c == nil {
+		err = errors.New("cron nil")
+		c.logger.Error(err)
+		return
+	}
+
+	if spec == "" {
+		err = errors.New("spec empty")
+		c.logger.Error(err)
+		return
+	}
+
+	c.locker.Lock()
+	defer c.unlock()
+
+	job, err := c.lookup(id)
+	if err == nil && job.Deleted {
+		err = errors.New("job removed")
+	}
+	if err == nil && job.completed {
+		// its window or max runs are used up, a new spec does not help
+		err = errors.New("job completed")
+	}
+	if err != nil {
+		c.logger.Error(err)
+		return
+	}
+
+	// parse first so a bad spec leaves the job untouched
+	parsed := &Job{id: job.id, name: job.name, nowFunc: job.nowFunc, location: job.location}
+	if err = parsed.Init(spec, c.interval, job.divisibility); err != nil {
+		c.logger.Error(err)
+		return
+	}
+
+	job.spec = parsed.spec
+	job.clock = parsed.clock
+	job.solar = parsed.solar
+	job.everyType = parsed.everyType
+	job.everyValue = parsed.everyValue
+	job.manual = parsed.manual
+	job.nextTime = parsed.nextTime
+	job.forget()
+
+	// a stopped cron computes the slot when it starts
+	if c.turning {
+		if err = job.Next(c.interval); err != nil {
+			c.logger.Error(err)
+			return
+		}
+		job.forget()
+		c.settle(job)
+	}
+	c.logger.Info("job updated:", id, job.nextTime)
+	return
+}
+
+func (c *Cron) MustReplaceCallback(id uint32, callback Callback) {
+	_ = c.ReplaceCallback(id, callback)
+}
+
+// ReplaceCallback swaps the callback of the job, runs in flight finish with
+// the old one.
+func (c *Cron) ReplaceCallback(id uint32, callback Callback) (err error) {
+	if callback == nil {
+		err = errors.New("callback is nil")
+		c.logger.Error(err)
+		return
+	}
+
+	return c.replaceCallback(id, callback, callback.context())
+}
+
+func (c *Cron) MustReplaceContextCallback(id uint32, callback ContextCallback) {
+	_ = c.ReplaceContextCallback(id, callback)
+}
+
+// ReplaceContextCallback is ReplaceCallback for a ContextCallback.
+func (c *Cron) ReplaceContextCallback(id uint32, callback ContextCallback) (err error) {
+	return c.replaceCallback(id, nil, callback)
+}
+
+func (c *Cron) replaceCallback(id uint32, callback Callback, contextCallback ContextCallback) (err error) {
+	if c == nil {
+		err = errors.New("cron nil")
+		c.logger.Error(err)
+		return
+	}
+
+	if contextCallback == nil {
+		err = errors.New("callback is nil")
+		c.logger.Error(err)
+		return
+	}
+
+	c.locker.Lock()
+	defer c.unlock()
+
+	job, err := c.lookup(id)
+	if err == nil && job.Deleted {
+		err = errors.New("job removed")
+	}
+	if err != nil {
+		c.logger.Error(err)
+		return
+	}
+
+	job.locker.Lock()
+	defer job.locker.Unlock()
+
+	job.Callback = callback
+	job.callback = contextCallback
+	job.wrapped = c.wrap(contextCallback, job.chain)
+	c.logger.Info("job callback replaced:", id)
+	return
+}
@@ -0,0 +1,111 @@
+package cron
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCron_UpdateJob(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.Local)
+	clock := &fakeNow{now: now}
+	c := New(WithNowFunc(clock.Now))
+	c.turning = true
+	id := c.MustAddJob("every 1 minute", func() {})
+	job := c.jobs[id-1]
+	if err := job.Next(c.interval); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err := c.UpdateJob(id, "0 3 * * *"); err != nil {
+		t.Error(err)
+		return
+	}
+	if job.spec != "0 3 * * *" || job.everyValue != 0 {
+		t.Error("spec", job.spec)
+	}
+	if !job.nextTime.Equal(now.Add(3 * time.Hour)) {
+		t.Error("next time", job.nextTime)
+	}
+	if job.slot != SlotSinceEpoch(job.nextTime, c.interval) || uint64(job.Slot()) != job.slot {
+		t.Error("slot", job.slot, job.Slot())
+	}
+
+	if err := c.UpdateJob(id, "every x minute"); err == nil {
+		t.Error("expected err")
+	}
+	if job.spec != "0 3 * * *" {
+		t.Error("spec changed by bad spec", job.spec)
+	}
+	if err := c.UpdateJob(id+1, "every 1 minute"); err == nil {
+		t.Error("expected err")
+	}
+}
+
+func TestCron_UpdateJobCompleted(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.Local)
+	clock := &fakeNow{now: now}
+	c := New(WithNowFunc(clock.Now))
+	c.turning = true
+	var hooks, events atomic.Int32
+	c.AddListener(func(event Event) {
+		if event.Type == EventJobCompleted {
+			events.Add(1)
+		}
+	})
+	id := c.MustAddJob("every 1 minute", func() {}, JobMaxRuns(1), JobOnComplete(func(JobSnapshot) {
+		hooks.Add(1)
+	}))
+	for i := 0; i < 2; i++ {
+		now = clock.Add(time.Minute)
+		c.locker.Lock()
+		c.tick(SlotSinceEpoch(now, c.interval))
+		c.unlock()
+	}
+	c.wg.Wait()
+
+	if err := c.UpdateJob(id, "every 2 minutes"); err == nil {
+		t.Error("expected err")
+	}
+	time.Sleep(time.Millisecond * 10)
+	if hooks.Load() != 1 || events.Load() != 1 {
+		t.Error("hooks", hooks.Load(), "events", events.Load())
+	}
+	if s, _ := c.GetJob(id); s.State != JobStateCompleted || s.Spec != "every 1 minute" {
+		t.Errorf("%+v", s)
+	}
+}
+
+func TestCron_ReplaceCallback(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.Local)
+	clock := &fakeNow{now: now}
+	c := New(WithNowFunc(clock.Now))
+	var old, replaced atomic.Int32
+	id := c.MustAddJob("every 1 minute", func() {
+		old.Add(1)
+	})
+	job := c.jobs[id-1]
+	if err := job.Next(c.interval); err != nil {
+		t.Error(err)
+		return
+	}
+
+	now = clock.Add(time.Minute)
+	c.tick(SlotSinceEpoch(now, c.interval))
+	if err := c.ReplaceCallback(id, func() {
+		replaced.Add(1)
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+	now = clock.Add(time.Minute)
+	c.tick(SlotSinceEpoch(now, c.interval))
+	time.Sleep(time.Millisecond * 10)
+	if old.Load() != 1 || replaced.Load() != 1 {
+		t.Error("runs", old.Load(), replaced.Load())
+	}
+	if c.ReplaceCallback(id, nil) == nil {
+		t.Error("expected err")
+	}
+}
@@ -5,8 +5,19 @@ import (
 	"time"
 )
 
+func isLeap(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+func daysIn(year int, month time.Month) uint8 {
+	if month == time.February && isLeap(year) {
+		return 29
+	}
+	return [...]uint8{31, 28, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}[month-1]
+}
+
 func LastMoment(interval time.Duration) (lastMoment uint32) {
-	if time.Now().Year()%4 == 0 {
+	if isLeap(time.Now().Year()) {
 		lastMoment = 366 * 24 * 60
 	} else {
 		lastMoment = 365 * 24 * 60
@@ -28,3 +39,10 @@ func SlotSinceYear(now time.Time, interval time.Duration) (slot uint32) {
 	slot = uint32(math.Floor(now.Sub(year).Seconds()))
 	return
 }
+
+// SlotSinceEpoch is the slot of the wheel, counted from the unix epoch so it
+// never wraps at the end of a year.
+func SlotSinceEpoch(now time.Time, interval time.Duration) (slot uint64) {
+	slot = uint64(now.Unix()) / uint64(interval/time.Second)
+	return
+}